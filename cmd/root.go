@@ -4,6 +4,9 @@ import (
 	"os"
 
 	"github.com/ant31/bungen/generators/model"
+	"github.com/ant31/bungen/generators/named"
+	"github.com/ant31/bungen/generators/search"
+	"github.com/ant31/bungen/generators/validate"
 
 	"github.com/spf13/cobra"
 )
@@ -27,6 +30,9 @@ to quickly create a models for Bun [Postgres driver] https://github.com/uptrace/
 func init() {
 	root.AddCommand(
 		model.CreateCommand(),
+		named.CreateCommand(),
+		search.CreateCommand(),
+		validate.CreateCommand(),
 	)
 }
 
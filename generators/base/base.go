@@ -3,11 +3,11 @@ package base
 import (
 	"bytes"
 	"fmt"
-	"html/template"
 	"log"
 	"os"
 	"path"
 	"strings"
+	"text/template"
 
 	bungen "github.com/ant31/bungen/lib"
 	"github.com/ant31/bungen/model"
@@ -35,6 +35,9 @@ const (
 	// uuid type flag
 	uuidFlag = "uuid"
 
+	// net/netip types flag
+	netipFlag = "netip"
+
 	// custom types flag
 	customTypesFlag = "custom-types"
 
@@ -42,16 +45,62 @@ const (
 	withORM        = "with-orm"
 	withValidation = "with-validation"
 	withSearch     = "with-search"
+	withHooks      = "with-hooks"
+	withCopy       = "with-copy"
+	createdAt      = "created-at"
+	updatedAt      = "updated-at"
+	createdBy      = "created-by"
+	updatedBy      = "updated-by"
+	auditKey       = "audit-key"
 	relaxed        = "search-relaxed"
+	searchParams   = "search-params"
 	dbWrap         = "db-wrap"
 	keepPK         = "keep-pk"
 	noDiscard      = "no-discard"
 	noAlias        = "no-alias"
 	softDelete     = "soft-delete"
+	softDeleteName = "soft-delete-names"
+	softDeleteTbl  = "soft-delete-table"
+	versionName    = "version-names"
+	versionTable   = "version-table"
 	json           = "json"
 	jsonTag        = "json-tag"
+	typeOverride   = "type-override"
+	fieldName      = "field-name"
+	fieldTag       = "field-tag"
+	jsonStructs    = "json-structs"
+	jsonSchema     = "json-schema"
+	jsonSample     = "json-sample"
+	initialisms    = "initialisms"
+	inflection     = "inflection"
+	renameTable    = "rename-table"
+	renameColumn   = "rename-column"
+	embed          = "embed"
+	embedShared    = "embed-shared"
 )
 
+// NetIPTypes are types generated for network postgres types with --netip flag
+var NetIPTypes = map[string]string{
+	model.TypePGInet:     "Inet",
+	model.TypePGCidr:     "Cidr",
+	model.TypePGMacaddr:  "MacAddr",
+	model.TypePGMacaddr8: "MacAddr",
+}
+
+// conventional columns filled by hooks
+var (
+	DefaultCreatedAt = []string{"created_at"}
+	DefaultUpdatedAt = []string{"updated_at"}
+	DefaultCreatedBy = []string{"created_by"}
+	DefaultUpdatedBy = []string{"updated_by"}
+)
+
+// DefaultSoftDeleteNames are conventional soft delete columns
+var DefaultSoftDeleteNames = []string{"deleted_at", "is_deleted"}
+
+// DefaultVersionNames are conventional optimistic locking columns
+var DefaultVersionNames = []string{"version", "lock_version"}
+
 // Gen is interface for all generators
 type Gen interface {
 	AddFlags(command *cobra.Command)
@@ -86,12 +135,25 @@ type Options struct {
 	// Do not replace primary key name to ID
 	KeepPK bool
 
-	// Soft delete column
+	// Soft delete column, overrides SoftDeleteNames
 	SoftDelete string
+	// Conventional soft delete columns, the first one found in table is used
+	// nullable timestamp, not null boolean or not null integer unix time
+	SoftDeleteNames []string
+	// Soft delete columns of tables, format: schema.table=column, "-" disables soft delete
+	SoftDeletes map[string]string
+
+	// Conventional optimistic locking columns, the first not null integer found in table is used
+	VersionNames []string
+	// Optimistic locking columns of tables, format: schema.table=column, "-" disables locking
+	Versions map[string]string
 
 	// use sql.Null... instead of pointers
 	UseSQLNulls bool
 
+	// use net/netip based types for inet, cidr and macaddr
+	UseNetIP bool
+
 	// Do not generate alias tag
 	NoAlias bool
 
@@ -104,14 +166,62 @@ type Options struct {
 	// Add json tag to models
 	AddJSONTag bool
 
+	// Override Go type for any column
+	// format: schema.table.column=[import/path.]Type, wildcards allowed
+	TypeOverrides map[string]string
+
+	// Override Go field name for any column
+	FieldNames map[string]string
+
+	// Additional tags for any column
+	// format: schema.table.column=name:value [name2:value2]
+	FieldTags map[string]string
+
+	// Generate go structs for json columns
+	// from JSON Schema file, JSON Schema in column comment or sampled rows
+	JSONStructs bool
+	// JSON Schema files for json columns, format: schema.table.column=path
+	JSONSchemas map[string]string
+	// Number of rows sampled to infer json structs, 0 disables sampling
+	JSONSample int
+
+	// Initialisms added to model.DefaultInitialisms, e.g. HTTP for HttpStatus -> HTTPStatus
+	Initialisms []string
+	// Singular=plural word pairs used to name entities, same word for uncountable
+	Inflections map[string]string
+	// Go names for tables, format: schema.table=Name
+	TableNames map[string]string
+	// Go names for columns in every table, format: column=Name
+	ColumnNames map[string]string
+
+	// Structs embedded into models instead of shared columns, format: Name=column [column2]
+	Embeds map[string]string
+	// Minimal number of tables sharing columns to extract them into embedded struct, 0 disables detection
+	EmbedShared int
+
 	// Generate basic ORM queries
 	WithORM bool
 	// Generate Search queries
 	WithSearch bool
 	// Generate Vallidation functions
 	WithValidation bool
+	// Generate BeforeAppendModel hooks
+	WithHooks bool
+	// Generate COPY based bulk loaders and exporters
+	WithCopy bool
+	// Columns filled by hooks: with current time on insert if empty, on insert and update,
+	// with user from context on insert, on insert and update
+	CreatedAt []string
+	UpdatedAt []string
+	CreatedBy []string
+	UpdatedBy []string
+	// Context key of user filled by hooks, go expression with optional import, e.g. github.com/acme/auth.UserKey
+	AuditKey string
 	// Strict types in filters
 	Relaxed bool
+	// Operators allowed in search query parameters, all are allowed if empty
+	// format: schema.table.column=op [op2], wildcards allowed
+	SearchParams map[string]string
 
 	// Struct name for ORM queries. Works only when GenORM == true
 	DBWrapName string
@@ -128,6 +238,32 @@ func (o *Options) Def() {
 	if o.CustomTypes == nil {
 		o.CustomTypes = model.CustomTypeMapping{}
 	}
+
+	if o.CreatedAt == nil {
+		o.CreatedAt = DefaultCreatedAt
+	}
+	if o.UpdatedAt == nil {
+		o.UpdatedAt = DefaultUpdatedAt
+	}
+	if o.CreatedBy == nil {
+		o.CreatedBy = DefaultCreatedBy
+	}
+	if o.UpdatedBy == nil {
+		o.UpdatedBy = DefaultUpdatedBy
+	}
+
+	if o.SoftDeleteNames == nil {
+		o.SoftDeleteNames = DefaultSoftDeleteNames
+	}
+
+	if o.VersionNames == nil {
+		o.VersionNames = DefaultVersionNames
+	}
+}
+
+// Naming creates naming strategy from options
+func (o *Options) Naming() model.NamingStrategy {
+	return model.NewNaming(o.Initialisms, o.Inflections, o.TableNames, o.ColumnNames)
 }
 
 // Generator is base generator used in other generators
@@ -159,11 +295,13 @@ func AddFlags(command *cobra.Command) {
 	}
 
 	flags.StringP(Pkg, "p", "", "package for model files. if not set last folder name in output path will be used")
+	flags.StringP(ConfigFlag, "C", "", "JSON config file with values of other flags, e.g. {\"type-override\": {\"users.amount\": \"github.com/acme/money.Amount\"}}\nflags set in command line take precedence")
 
 	flags.StringSliceP(Tables, "t", []string{"public.*"}, "table names for model generation separated by comma\nuse 'schema_name.*' to generate model for every table in model")
 	flags.BoolP(FollowFKs, "f", false, "generate models for foreign keys, even if it not listed in Tables\n")
 
 	flags.Bool(uuidFlag, false, "use github.com/google/uuid as type for uuid")
+	flags.Bool(netipFlag, false, "use net/netip based types for inet, cidr and macaddr (Inet, Cidr and MacAddr are generated)")
 
 	flags.StringSlice(customTypesFlag, []string{}, "set custom types separated by comma\nformat: <postgresql_type>:<go_import>.<go_type>\nexamples: uuid:github.com/google/uuid.UUID,point:src/model.Point,bytea:string\n")
 
@@ -171,15 +309,43 @@ func AddFlags(command *cobra.Command) {
 	flags.StringP(dbWrap, "z", "DBWrap", "name of structs for wrapping ORM queries (works only with flag -q, --gen-orm)")
 	flags.Bool(withSearch, false, "generate basic Search queries")
 	flags.Bool(withValidation, false, "generate model Validation methods")
-	flags.Bool(relaxed, false, "use interface{} type in search filters\n")
+	flags.Bool(withHooks, false, "generate BeforeAppendModel hooks filling timestamp and audit columns on insert and update")
+	flags.Bool(withCopy, false, "generate Copy and CopyOut functions loading and exporting rows with COPY (pgdriver only)")
+	flags.StringSlice(createdAt, DefaultCreatedAt, "columns set to current time on insert if empty (works only with --with-hooks)")
+	flags.StringSlice(updatedAt, DefaultUpdatedAt, "columns set to current time on insert and update (works only with --with-hooks)")
+	flags.StringSlice(createdBy, DefaultCreatedBy, "columns set to user from context on insert (works only with --with-hooks)")
+	flags.StringSlice(updatedBy, DefaultUpdatedBy, "columns set to user from context on insert and update (works only with --with-hooks)")
+	flags.String(auditKey, "", "context key of user for created-by and updated-by columns, generated AuditKey is used if not set\nexample: github.com/acme/auth.UserKey")
+	flags.Bool(relaxed, false, "use interface{} type in search filters")
+	flags.StringToString(searchParams, map[string]string{}, "operators allowed in search query parameters, all are allowed if not set\nuse format: schema.table.column=op, separate by comma\nuse space to allow several operators, asterisk to allow all of them\noperators: eq in not_in gt gte lt lte ilike prefix is_null contains overlaps has_key\n")
 	flags.BoolP(keepPK, "k", false, "keep primary key name as is (by default it should be converted to 'ID')")
-	flags.StringP(softDelete, "s", "", "field for soft_delete tag\n")
+	flags.StringP(softDelete, "s", "", "soft delete column in every table, overrides --soft-delete-names")
+	flags.StringSlice(softDeleteName, DefaultSoftDeleteNames, "conventional soft delete columns: nullable timestamp, not null boolean or not null integer unix time")
+	flags.StringToString(softDeleteTbl, map[string]string{}, "soft delete columns of tables\nuse format: schema.table=column, separate by comma\nuse '-' to disable soft delete of table\n")
+	flags.StringSlice(versionName, DefaultVersionNames, "conventional optimistic locking columns: not null integer checked and incremented by update and delete queries")
+	flags.StringToString(versionTable, map[string]string{}, "optimistic locking columns of tables\nuse format: schema.table=column, separate by comma\nuse '-' to disable locking of table\n")
 
 	flags.BoolP(noAlias, "w", false, `do not set 'alias' tag to "t"`)
 	flags.BoolP(noDiscard, "d", false, "do not use 'discard_unknown_columns' tag\n")
 
 	flags.StringToStringP(json, "j", map[string]string{"*": "map[string]interface{}"}, "type for json columns\nuse format: table.column=type, separate by comma\nuse asterisk as wildcard in table name")
-	flags.Bool(jsonTag, false, "add json tag to annotations")
+	flags.Bool(jsonTag, false, "add json tag to annotations\n")
+
+	flags.StringToString(typeOverride, map[string]string{}, "type for any column\nuse format: schema.table.column=type, separate by comma\ntype may contain import: users.amount=github.com/acme/money.Amount\nuse asterisk as wildcard in table or column name")
+	flags.StringToString(fieldName, map[string]string{}, "go field name for columns\nuse format: schema.table.column=Name, separate by comma")
+	flags.StringToString(fieldTag, map[string]string{}, "additional tags for columns\nuse format: schema.table.column=name:value, separate by comma\nuse space to add several tags to one column\n")
+
+	flags.Bool(jsonStructs, false, "generate go structs for json columns\nfrom JSON Schema file, JSON Schema in column comment or sampled rows")
+	flags.StringToString(jsonSchema, map[string]string{}, "JSON Schema files for json columns (works only with --json-structs)\nuse format: schema.table.column=path, separate by comma")
+	flags.Int(jsonSample, 0, "number of rows sampled to infer json structs if no JSON Schema found (works only with --json-structs)\n")
+
+	flags.StringSlice(initialisms, []string{}, "initialisms used in go names in addition to common ones (ID, URL, HTTP, API...)\nexample: SKU,VAT")
+	flags.StringToString(inflection, map[string]string{}, "singular and plural forms used to name entities\nuse format: singular=plural, separate by comma\nuse the same word for uncountable: metadata=metadata")
+	flags.StringToString(renameTable, map[string]string{}, "go names for tables\nuse format: schema.table=Name, separate by comma")
+	flags.StringToString(renameColumn, map[string]string{}, "go names for columns in every table, also used to name relations\nuse format: column=Name, separate by comma\n")
+
+	flags.StringToString(embed, map[string]string{}, "structs embedded into models instead of columns shared by tables\nuse format: Name=column [column2], separate by comma\nexample: Timestamps=created_at updated_at")
+	flags.Int(embedShared, 0, "extract columns shared by at least this number of tables into embedded structs, 0 disables detection\n")
 
 	return
 }
@@ -191,6 +357,16 @@ func ReadFlags(command *cobra.Command, o *Options) (err error) {
 
 	flags := command.Flags()
 
+	config, err := flags.GetString(ConfigFlag)
+	if err != nil {
+		return
+	}
+	if config != "" {
+		if err = LoadConfig(command, config); err != nil {
+			return
+		}
+	}
+
 	if o.URL, err = flags.GetString(Conn); err != nil {
 		return
 	}
@@ -229,9 +405,37 @@ func ReadFlags(command *cobra.Command, o *Options) (err error) {
 	if o.WithValidation, err = flags.GetBool(withValidation); err != nil {
 		return
 	}
+
+	if o.WithHooks, err = flags.GetBool(withHooks); err != nil {
+		return
+	}
+	if o.WithCopy, err = flags.GetBool(withCopy); err != nil {
+		return
+	}
+	if o.CreatedAt, err = flags.GetStringSlice(createdAt); err != nil {
+		return
+	}
+	if o.UpdatedAt, err = flags.GetStringSlice(updatedAt); err != nil {
+		return
+	}
+	if o.CreatedBy, err = flags.GetStringSlice(createdBy); err != nil {
+		return
+	}
+	if o.UpdatedBy, err = flags.GetStringSlice(updatedBy); err != nil {
+		return
+	}
+	if o.AuditKey, err = flags.GetString(auditKey); err != nil {
+		return
+	}
+	if _, _, err = model.ParseGoType(o.AuditKey); err != nil {
+		return fmt.Errorf("audit key %s: %w", o.AuditKey, err)
+	}
 	if o.Relaxed, err = flags.GetBool(relaxed); err != nil {
 		return
 	}
+	if o.SearchParams, err = flags.GetStringToString(searchParams); err != nil {
+		return
+	}
 
 	if customTypesStrings, err = flags.GetStringSlice(customTypesFlag); err != nil {
 		return
@@ -248,6 +452,18 @@ func ReadFlags(command *cobra.Command, o *Options) (err error) {
 		o.CustomTypes.Add(model.TypePGUuid, "uuid.UUID", "github.com/google/uuid")
 	}
 
+	if o.UseNetIP, err = flags.GetBool(netipFlag); err != nil {
+		return
+	}
+
+	if o.UseNetIP {
+		for pgType, goType := range NetIPTypes {
+			if !o.CustomTypes.Has(pgType) {
+				o.CustomTypes.Add(pgType, goType, "")
+			}
+		}
+	}
+
 	if o.KeepPK, err = flags.GetBool(keepPK); err != nil {
 		return err
 	}
@@ -256,6 +472,22 @@ func ReadFlags(command *cobra.Command, o *Options) (err error) {
 		return err
 	}
 
+	if o.SoftDeleteNames, err = flags.GetStringSlice(softDeleteName); err != nil {
+		return err
+	}
+
+	if o.SoftDeletes, err = flags.GetStringToString(softDeleteTbl); err != nil {
+		return err
+	}
+
+	if o.VersionNames, err = flags.GetStringSlice(versionName); err != nil {
+		return err
+	}
+
+	if o.Versions, err = flags.GetStringToString(versionTable); err != nil {
+		return err
+	}
+
 	if o.NoDiscard, err = flags.GetBool(noDiscard); err != nil {
 		return err
 	}
@@ -272,6 +504,64 @@ func ReadFlags(command *cobra.Command, o *Options) (err error) {
 		return err
 	}
 
+	if o.TypeOverrides, err = flags.GetStringToString(typeOverride); err != nil {
+		return err
+	}
+	for pattern, typ := range o.TypeOverrides {
+		if _, _, err = model.ParseGoType(typ); err != nil {
+			return fmt.Errorf("type override for %s: %w", pattern, err)
+		}
+	}
+
+	if o.FieldNames, err = flags.GetStringToString(fieldName); err != nil {
+		return err
+	}
+
+	if o.FieldTags, err = flags.GetStringToString(fieldTag); err != nil {
+		return err
+	}
+	for pattern, tags := range o.FieldTags {
+		if _, err = util.ParseAnnotation(tags); err != nil {
+			return fmt.Errorf("field tag for %s: %w", pattern, err)
+		}
+	}
+
+	if o.JSONStructs, err = flags.GetBool(jsonStructs); err != nil {
+		return err
+	}
+
+	if o.JSONSchemas, err = flags.GetStringToString(jsonSchema); err != nil {
+		return err
+	}
+
+	if o.JSONSample, err = flags.GetInt(jsonSample); err != nil {
+		return err
+	}
+
+	if o.Initialisms, err = flags.GetStringSlice(initialisms); err != nil {
+		return err
+	}
+
+	if o.Inflections, err = flags.GetStringToString(inflection); err != nil {
+		return err
+	}
+
+	if o.TableNames, err = flags.GetStringToString(renameTable); err != nil {
+		return err
+	}
+
+	if o.ColumnNames, err = flags.GetStringToString(renameColumn); err != nil {
+		return err
+	}
+
+	if o.Embeds, err = flags.GetStringToString(embed); err != nil {
+		return err
+	}
+
+	if o.EmbedShared, err = flags.GetInt(embedShared); err != nil {
+		return err
+	}
+
 	return
 }
 
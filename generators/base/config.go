@@ -0,0 +1,127 @@
+package base
+
+import (
+	// json is a name of flag in this package
+	encjson "encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// ConfigFlag is a flag (-C) with path to JSON config file
+const ConfigFlag = "config"
+
+// LoadConfig sets flags of command from JSON config file, flags set in command line are kept
+// keys are flag names, values are strings, numbers or booleans, lists for slice flags and objects for map flags, e.g.
+// {"with-orm": true, "tables": ["public.*"], "type-override": {"billing.invoices.amount": "github.com/acme/money.Amount"}}
+func LoadConfig(command *cobra.Command, path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("reading config error: %w", err)
+	}
+
+	var config map[string]interface{}
+	if err := encjson.Unmarshal(data, &config); err != nil {
+		return fmt.Errorf("parsing config %s error: %w", path, err)
+	}
+
+	names := make([]string, 0, len(config))
+	for name := range config {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	flags := command.Flags()
+	for _, name := range names {
+		flag := flags.Lookup(name)
+		if flag == nil || name == ConfigFlag {
+			return fmt.Errorf("config %s: unknown flag %s", path, name)
+		}
+		if flag.Changed {
+			continue
+		}
+
+		values, err := configValues(config[name])
+		if err != nil {
+			return fmt.Errorf("config %s: flag %s: %w", path, name, err)
+		}
+		for _, value := range values {
+			if err := flags.Set(name, value); err != nil {
+				return fmt.Errorf("config %s: flag %s: %w", path, name, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// configValues converts config value to flag values, every element of list and object is set separately
+func configValues(value interface{}) ([]string, error) {
+	switch v := value.(type) {
+	case []interface{}:
+		result := make([]string, 0, len(v))
+		for _, item := range v {
+			text, err := configScalar(item)
+			if err != nil {
+				return nil, err
+			}
+			result = append(result, csvQuote(text))
+		}
+		return result, nil
+	case map[string]interface{}:
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		result := make([]string, 0, len(v))
+		for _, key := range keys {
+			text, err := configScalar(v[key])
+			if err != nil {
+				return nil, err
+			}
+			// pair with one equal sign is not parsed as comma separated values
+			pair := key + "=" + text
+			if strings.Count(pair, "=") > 1 {
+				pair = csvQuote(pair)
+			}
+			result = append(result, pair)
+		}
+		return result, nil
+	}
+
+	text, err := configScalar(value)
+	if err != nil {
+		return nil, err
+	}
+
+	return []string{text}, nil
+}
+
+// configScalar converts string, number or boolean to flag value
+func configScalar(value interface{}) (string, error) {
+	switch v := value.(type) {
+	case string:
+		return v, nil
+	case bool:
+		return strconv.FormatBool(v), nil
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64), nil
+	}
+
+	return "", fmt.Errorf("unsupported value %v", value)
+}
+
+// csvQuote quotes element of slice and map flags, they are parsed as comma separated values
+func csvQuote(value string) string {
+	if !strings.ContainsAny(value, ",\"\n") {
+		return value
+	}
+
+	return `"` + strings.ReplaceAll(value, `"`, `""`) + `"`
+}
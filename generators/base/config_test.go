@@ -0,0 +1,66 @@
+package base
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/spf13/cobra"
+)
+
+func TestLoadConfig(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "bungen.json")
+	config := `{
+		"with-orm": true,
+		"json-sample": 5,
+		"tables": ["public.*", "billing.*"],
+		"keep-pk": true,
+		"type-override": {"billing.invoices.amount": "github.com/acme/money.Amount"},
+		"field-tag": {"users.email": "validate:email,required", "users.name": "pg:name=full_name"}
+	}`
+	if err := os.WriteFile(path, []byte(config), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	command := &cobra.Command{}
+	AddFlags(command)
+	if err := command.ParseFlags([]string{"-c", "postgres://localhost/db", "-o", "model.go", "--keep-pk=false", "-C", path}); err != nil {
+		t.Fatal(err)
+	}
+
+	var o Options
+	if err := ReadFlags(command, &o); err != nil {
+		t.Fatalf("ReadFlags() error = %v", err)
+	}
+
+	if !o.WithORM || o.JSONSample != 5 {
+		t.Errorf("ReadFlags() scalars = %v, %v", o.WithORM, o.JSONSample)
+	}
+	if want := []string{"public.*", "billing.*"}; !reflect.DeepEqual(o.Tables, want) {
+		t.Errorf("ReadFlags() tables = %v, want %v", o.Tables, want)
+	}
+	// command line goes first
+	if o.KeepPK {
+		t.Errorf("ReadFlags() config overrides command line")
+	}
+	if want := map[string]string{"billing.invoices.amount": "github.com/acme/money.Amount"}; !reflect.DeepEqual(o.TypeOverrides, want) {
+		t.Errorf("ReadFlags() type overrides = %v, want %v", o.TypeOverrides, want)
+	}
+	if want := map[string]string{"users.email": "validate:email,required", "users.name": "pg:name=full_name"}; !reflect.DeepEqual(o.FieldTags, want) {
+		t.Errorf("ReadFlags() field tags = %v, want %v", o.FieldTags, want)
+	}
+}
+
+func TestLoadConfigUnknownFlag(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "bungen.json")
+	if err := os.WriteFile(path, []byte(`{"with-rom": true}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	command := &cobra.Command{}
+	AddFlags(command)
+	if err := LoadConfig(command, path); err == nil {
+		t.Errorf("LoadConfig() expected error for unknown flag")
+	}
+}
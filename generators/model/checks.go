@@ -0,0 +1,1029 @@
+package model
+
+import (
+	"fmt"
+	"html/template"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/ant31/bungen/model"
+)
+
+// TemplateUnchecked stores CHECK constraint which can't be translated into go code
+type TemplateUnchecked struct {
+	Name       string
+	Definition template.HTML
+	Reason     string
+}
+
+// newTemplateConstraintChecks translates CHECK constraints of table and domains of columns into validation rules
+func newTemplateConstraintChecks(entity model.Entity, columns []TemplateColumn) ([]TemplateCheck, []TemplateUnchecked) {
+	index := map[string]TemplateColumn{}
+	for _, column := range columns {
+		index[column.PGName] = column
+	}
+
+	var (
+		checks    []TemplateCheck
+		unchecked []TemplateUnchecked
+	)
+	add := func(name, definition string, value *TemplateColumn) {
+		translator := &checkTranslator{columns: index, value: value}
+		cond, err := translator.translate(definition)
+		if err != nil {
+			unchecked = append(unchecked, TemplateUnchecked{
+				Name:       name,
+				Definition: template.HTML(strings.Join(strings.Fields(definition), " ")),
+				Reason:     err.Error(),
+			})
+			return
+		}
+
+		checks = append(checks, TemplateCheck{
+			Column:  translator.column(),
+			Cond:    template.HTML(cond),
+			Code:    "ValidationCheck",
+			Message: template.HTML(strconv.Quote("violates check constraint " + name)),
+		})
+	}
+
+	for _, column := range columns {
+		if column.Domain == nil {
+			continue
+		}
+		for _, definition := range column.Domain.Checks {
+			value := column
+			add(column.Domain.PGName, definition, &value)
+		}
+	}
+
+	for _, check := range entity.Checks() {
+		add(check.Name, check.Definition, nil)
+	}
+
+	return checks, unchecked
+}
+
+// UntranslatedChecks lists CHECK constraints which are not validated by generated code
+func UntranslatedChecks(entities []model.Entity, options Options) []string {
+	var result []string
+	for _, entity := range entities {
+		for _, unchecked := range NewTemplateEntity(entity, options).Unchecked {
+			result = append(result, fmt.Sprintf("check constraint %s of %s is not validated: %s", unchecked.Name, entity.PGFullName, unchecked.Reason))
+		}
+	}
+	return result
+}
+
+// check expression tokens
+const (
+	checkIdent = iota
+	checkQuoted
+	checkString
+	checkNumber
+	checkOp
+	checkPunct
+	checkEOF
+)
+
+type checkToken struct {
+	kind int
+	text string
+}
+
+const checkOpChars = "<>=!~*+-/%"
+
+// tokenizeCheck splits constraint definition produced by pg_get_constraintdef
+func tokenizeCheck(s string) ([]checkToken, error) {
+	var tokens []checkToken
+	runes := []rune(s)
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case r == '\'' || r == '"':
+			var b strings.Builder
+			j := i + 1
+			for ; j < len(runes); j++ {
+				if runes[j] == r {
+					if j+1 < len(runes) && runes[j+1] == r {
+						b.WriteRune(r)
+						j++
+						continue
+					}
+					break
+				}
+				b.WriteRune(runes[j])
+			}
+			if j >= len(runes) {
+				return nil, fmt.Errorf("unterminated %c", r)
+			}
+			kind := checkString
+			if r == '"' {
+				kind = checkQuoted
+			}
+			tokens = append(tokens, checkToken{kind: kind, text: b.String()})
+			i = j + 1
+		case unicode.IsLetter(r) || r == '_':
+			j := i
+			for j < len(runes) && (unicode.IsLetter(runes[j]) || unicode.IsDigit(runes[j]) || runes[j] == '_' || runes[j] == '$') {
+				j++
+			}
+			tokens = append(tokens, checkToken{kind: checkIdent, text: string(runes[i:j])})
+			i = j
+		case unicode.IsDigit(r):
+			j := i
+			for j < len(runes) && (unicode.IsDigit(runes[j]) || runes[j] == '.') {
+				j++
+			}
+			tokens = append(tokens, checkToken{kind: checkNumber, text: string(runes[i:j])})
+			i = j
+		case r == ':' && i+1 < len(runes) && runes[i+1] == ':':
+			tokens = append(tokens, checkToken{kind: checkPunct, text: "::"})
+			i += 2
+		case strings.ContainsRune("()[],.", r):
+			tokens = append(tokens, checkToken{kind: checkPunct, text: string(r)})
+			i++
+		case strings.ContainsRune(checkOpChars, r):
+			j := i
+			for j < len(runes) && strings.ContainsRune(checkOpChars, runes[j]) {
+				j++
+			}
+			tokens = append(tokens, checkToken{kind: checkOp, text: string(runes[i:j])})
+			i = j
+		default:
+			return nil, fmt.Errorf("unexpected character %q", r)
+		}
+	}
+
+	return append(tokens, checkToken{kind: checkEOF}), nil
+}
+
+// check expression nodes
+const (
+	nodeAnd = iota
+	nodeOr
+	nodeNot
+	nodeCompare
+	nodeIsNull
+	nodeAny
+	nodeAll
+	nodeColumn
+	nodeValue
+	nodeNumber
+	nodeString
+	nodeBool
+	nodeArray
+	nodeCast
+	nodeFunc
+)
+
+type checkNode struct {
+	kind int
+	// op is an operator of comparison
+	op string
+	// text is a column or function name, literal or cast type
+	text string
+	args []*checkNode
+}
+
+type checkParser struct {
+	tokens []checkToken
+	pos    int
+}
+
+func (p *checkParser) peek() checkToken {
+	return p.tokens[p.pos]
+}
+
+func (p *checkParser) next() checkToken {
+	token := p.tokens[p.pos]
+	if token.kind != checkEOF {
+		p.pos++
+	}
+	return token
+}
+
+// keyword consumes case insensitive keywords if all of them follow
+func (p *checkParser) keyword(words ...string) bool {
+	for i, word := range words {
+		token := p.tokens[p.pos+i]
+		if token.kind != checkIdent || !strings.EqualFold(token.text, word) {
+			return false
+		}
+	}
+	p.pos += len(words)
+	return true
+}
+
+func (p *checkParser) punct(text string) bool {
+	if token := p.peek(); token.kind == checkPunct && token.text == text {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+func (p *checkParser) expect(text string) error {
+	if !p.punct(text) {
+		return fmt.Errorf("%q expected, got %q", text, p.peek().text)
+	}
+	return nil
+}
+
+func (p *checkParser) parseOr() (*checkNode, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.keyword("OR") {
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = &checkNode{kind: nodeOr, args: []*checkNode{left, right}}
+	}
+	return left, nil
+}
+
+func (p *checkParser) parseAnd() (*checkNode, error) {
+	left, err := p.parseNot()
+	if err != nil {
+		return nil, err
+	}
+	for p.keyword("AND") {
+		right, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		left = &checkNode{kind: nodeAnd, args: []*checkNode{left, right}}
+	}
+	return left, nil
+}
+
+func (p *checkParser) parseNot() (*checkNode, error) {
+	if p.keyword("NOT") {
+		arg, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		return &checkNode{kind: nodeNot, args: []*checkNode{arg}}, nil
+	}
+	return p.parseCompare()
+}
+
+// likeOps are operators LIKE and ILIKE are printed as
+var likeOps = map[string]string{"LIKE": "~~", "ILIKE": "~~*"}
+
+func (p *checkParser) parseCompare() (*checkNode, error) {
+	left, err := p.parseOperand()
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case p.keyword("IS", "NULL"):
+		return &checkNode{kind: nodeIsNull, args: []*checkNode{left}}, nil
+	case p.keyword("IS", "NOT", "NULL"):
+		return &checkNode{kind: nodeNot, args: []*checkNode{{kind: nodeIsNull, args: []*checkNode{left}}}}, nil
+	}
+
+	not := p.keyword("NOT")
+	negate := func(node *checkNode) *checkNode {
+		if not {
+			return &checkNode{kind: nodeNot, args: []*checkNode{node}}
+		}
+		return node
+	}
+
+	switch {
+	case p.keyword("BETWEEN"):
+		if p.keyword("SYMMETRIC") {
+			return nil, fmt.Errorf("BETWEEN SYMMETRIC is not supported")
+		}
+		low, err := p.parseOperand()
+		if err != nil {
+			return nil, err
+		}
+		if !p.keyword("AND") {
+			return nil, fmt.Errorf("AND of BETWEEN expected")
+		}
+		high, err := p.parseOperand()
+		if err != nil {
+			return nil, err
+		}
+		return negate(&checkNode{kind: nodeAnd, args: []*checkNode{
+			{kind: nodeCompare, op: ">=", args: []*checkNode{left, low}},
+			{kind: nodeCompare, op: "<=", args: []*checkNode{left, high}},
+		}}), nil
+	case p.keyword("IN"):
+		if err := p.expect("("); err != nil {
+			return nil, err
+		}
+		items, err := p.parseList(")")
+		if err != nil {
+			return nil, err
+		}
+		return negate(&checkNode{kind: nodeAny, op: "=", args: []*checkNode{left, {kind: nodeArray, args: items}}}), nil
+	}
+	for word, op := range likeOps {
+		if p.keyword(word) {
+			right, err := p.parseOperand()
+			if err != nil {
+				return nil, err
+			}
+			return negate(&checkNode{kind: nodeCompare, op: op, args: []*checkNode{left, right}}), nil
+		}
+	}
+	if not {
+		return nil, fmt.Errorf("unexpected NOT")
+	}
+
+	token := p.peek()
+	if token.kind != checkOp {
+		return left, nil
+	}
+	p.next()
+
+	kind := 0
+	switch {
+	case p.keyword("ANY"), p.keyword("SOME"):
+		kind = nodeAny
+	case p.keyword("ALL"):
+		kind = nodeAll
+	}
+	if kind != 0 {
+		if err := p.expect("("); err != nil {
+			return nil, err
+		}
+		array, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expect(")"); err != nil {
+			return nil, err
+		}
+		return &checkNode{kind: kind, op: token.text, args: []*checkNode{left, array}}, nil
+	}
+
+	right, err := p.parseOperand()
+	if err != nil {
+		return nil, err
+	}
+	return &checkNode{kind: nodeCompare, op: token.text, args: []*checkNode{left, right}}, nil
+}
+
+// parseList parses comma separated expressions till closing bracket
+func (p *checkParser) parseList(closing string) ([]*checkNode, error) {
+	var items []*checkNode
+	if p.punct(closing) {
+		return items, nil
+	}
+	for {
+		item, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+		if p.punct(closing) {
+			return items, nil
+		}
+		if err := p.expect(","); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// checkKeywords can't be a part of cast type
+var checkKeywords = []string{"AND", "OR", "NOT", "IS", "IN", "BETWEEN", "LIKE", "ILIKE", "ANY", "ALL", "SOME"}
+
+func (p *checkParser) parseOperand() (*checkNode, error) {
+	node, err := p.parsePrimary()
+	if err != nil {
+		return nil, err
+	}
+
+	for p.punct("::") {
+		var words []string
+		for {
+			token := p.peek()
+			if token.kind == checkQuoted || token.kind == checkIdent && !isCheckKeyword(token.text) {
+				words = append(words, strings.ToLower(p.next().text))
+				continue
+			}
+			if token.kind == checkPunct && token.text == "." {
+				p.next()
+				continue
+			}
+			break
+		}
+		if len(words) == 0 {
+			return nil, fmt.Errorf("type expected after ::")
+		}
+		// type modifiers, e.g. numeric(10,2)
+		if p.punct("(") {
+			if _, err := p.parseList(")"); err != nil {
+				return nil, err
+			}
+		}
+		typ := strings.Join(words, " ")
+		for p.punct("[") {
+			if err := p.expect("]"); err != nil {
+				return nil, err
+			}
+			typ += "[]"
+		}
+		node = &checkNode{kind: nodeCast, text: typ, args: []*checkNode{node}}
+	}
+
+	return node, nil
+}
+
+func isCheckKeyword(word string) bool {
+	for _, keyword := range checkKeywords {
+		if strings.EqualFold(word, keyword) {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *checkParser) parsePrimary() (*checkNode, error) {
+	token := p.next()
+	switch token.kind {
+	case checkPunct:
+		if token.text != "(" {
+			break
+		}
+		node, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		return node, p.expect(")")
+	case checkOp:
+		if next := p.peek(); token.text == "-" && next.kind == checkNumber {
+			return &checkNode{kind: nodeNumber, text: "-" + p.next().text}, nil
+		}
+	case checkNumber:
+		return &checkNode{kind: nodeNumber, text: token.text}, nil
+	case checkString:
+		return &checkNode{kind: nodeString, text: token.text}, nil
+	case checkQuoted:
+		return &checkNode{kind: nodeColumn, text: token.text}, nil
+	case checkIdent:
+		switch strings.ToUpper(token.text) {
+		case "TRUE", "FALSE":
+			return &checkNode{kind: nodeBool, text: strings.ToLower(token.text)}, nil
+		case "VALUE":
+			return &checkNode{kind: nodeValue}, nil
+		case "ARRAY":
+			if err := p.expect("["); err != nil {
+				return nil, err
+			}
+			items, err := p.parseList("]")
+			if err != nil {
+				return nil, err
+			}
+			return &checkNode{kind: nodeArray, args: items}, nil
+		case "NULL":
+			return nil, fmt.Errorf("NULL literal is not supported")
+		}
+		if isCheckKeyword(token.text) {
+			break
+		}
+		if p.punct("(") {
+			args, err := p.parseList(")")
+			if err != nil {
+				return nil, err
+			}
+			return &checkNode{kind: nodeFunc, text: strings.ToLower(token.text), args: args}, nil
+		}
+		return &checkNode{kind: nodeColumn, text: token.text}, nil
+	}
+
+	return nil, fmt.Errorf("unexpected %q", token.text)
+}
+
+// operand kinds
+const (
+	kindString = "string"
+	kindInt    = "int"
+	kindFloat  = "float"
+	kindBool   = "bool"
+	kindTime   = "time"
+	kindArray  = "array"
+)
+
+// checkOperand is a go expression of operand
+type checkOperand struct {
+	expr string
+	kind string
+	// goType is a type of variable operand
+	goType string
+	// raw is a value of string literal
+	raw      string
+	constant bool
+	// notNull are conditions which are true if operand is not NULL, isNull are opposite ones
+	notNull []string
+	isNull  []string
+	// zero is an expression checking column value for NOT NULL columns, zero values are inserted as NULL
+	zero  string
+	items []*checkNode
+}
+
+type checkTranslator struct {
+	columns map[string]TemplateColumn
+	// value is a column checked by domain constraint
+	value *TemplateColumn
+	used  []TemplateColumn
+}
+
+// column gets first column referenced by constraint
+func (t *checkTranslator) column() TemplateColumn {
+	if t.value != nil {
+		return *t.value
+	}
+	return t.used[0]
+}
+
+// translate gets go expression which is true if constraint fails
+// CHECK fails only if expression is false, NULL passes
+func (t *checkTranslator) translate(definition string) (string, error) {
+	definition = strings.TrimSpace(definition)
+	for _, suffix := range []string{"NOT VALID", "NO INHERIT"} {
+		definition = strings.TrimSpace(strings.TrimSuffix(definition, suffix))
+	}
+	if !strings.HasPrefix(definition, "CHECK") {
+		return "", fmt.Errorf("CHECK expected")
+	}
+
+	tokens, err := tokenizeCheck(strings.TrimPrefix(definition, "CHECK"))
+	if err != nil {
+		return "", err
+	}
+
+	parser := &checkParser{tokens: tokens}
+	node, err := parser.parseOr()
+	if err != nil {
+		return "", err
+	}
+	if token := parser.peek(); token.kind != checkEOF {
+		return "", fmt.Errorf("unexpected %q", token.text)
+	}
+
+	cond, err := t.cond(node, true)
+	if err != nil {
+		return "", err
+	}
+	if t.value == nil && len(t.used) == 0 {
+		return "", fmt.Errorf("no columns are checked")
+	}
+
+	return cond, nil
+}
+
+// cond gets go expression which is true if node is TRUE, or if node is FALSE when negative is set
+func (t *checkTranslator) cond(node *checkNode, negative bool) (string, error) {
+	switch node.kind {
+	case nodeAnd, nodeOr:
+		left, err := t.cond(node.args[0], negative)
+		if err != nil {
+			return "", err
+		}
+		right, err := t.cond(node.args[1], negative)
+		if err != nil {
+			return "", err
+		}
+		// a AND b is false if any of them is false
+		if node.kind == nodeAnd != negative {
+			return joinConds(" && ", left, right), nil
+		}
+		return joinConds(" || ", left, right), nil
+	case nodeNot:
+		return t.cond(node.args[0], !negative)
+	case nodeIsNull:
+		operand, err := t.operand(node.args[0])
+		if err != nil {
+			return "", err
+		}
+		return operand.nullCond(negative), nil
+	case nodeCompare:
+		left, err := t.operand(node.args[0])
+		if err != nil {
+			return "", err
+		}
+		right, err := t.operand(node.args[1])
+		if err != nil {
+			return "", err
+		}
+		op := node.op
+		if negative {
+			op = negateOp(op)
+		}
+		cond, err := compare(op, left, right)
+		if err != nil {
+			return "", err
+		}
+		return atomCond(cond, left, right), nil
+	case nodeAny, nodeAll:
+		return t.condList(node, negative)
+	case nodeColumn, nodeValue, nodeBool, nodeCast, nodeFunc:
+		operand, err := t.operand(node)
+		if err != nil {
+			return "", err
+		}
+		if operand.kind != kindBool {
+			return "", fmt.Errorf("boolean expression expected, got %s", operand.kind)
+		}
+		cond := operand.expr
+		if negative {
+			cond = "!" + cond
+		}
+		return atomCond(cond, operand), nil
+	}
+
+	return "", fmt.Errorf("unsupported expression")
+}
+
+// condList translates x op ANY (array) and x op ALL (array)
+func (t *checkTranslator) condList(node *checkNode, negative bool) (string, error) {
+	left, err := t.operand(node.args[0])
+	if err != nil {
+		return "", err
+	}
+	array, err := t.operand(node.args[1])
+	if err != nil {
+		return "", err
+	}
+	if array.kind != kindArray {
+		return "", fmt.Errorf("array expected in %s ANY/ALL", node.op)
+	}
+
+	// IN and NOT IN lists of strings
+	in := node.kind == nodeAny && node.op == "=" || node.kind == nodeAll && (node.op == "<>" || node.op == "!=")
+	if in && left.kind == kindString {
+		values := []string{left.expr}
+		for _, item := range array.items {
+			operand, err := t.operand(item)
+			if err != nil {
+				return "", err
+			}
+			if !operand.constant || operand.kind != kindString {
+				return "", fmt.Errorf("list of string constants expected")
+			}
+			values = append(values, operand.expr)
+		}
+
+		cond := fmt.Sprintf("oneOf(%s)", strings.Join(values, ", "))
+		if node.kind == nodeAll != negative {
+			cond = "!" + cond
+		}
+		return atomCond(cond, left), nil
+	}
+
+	if len(array.items) == 0 {
+		return "", fmt.Errorf("empty list is not supported")
+	}
+	kind := nodeOr
+	if node.kind == nodeAll {
+		kind = nodeAnd
+	}
+	var expanded *checkNode
+	for _, item := range array.items {
+		cmp := &checkNode{kind: nodeCompare, op: node.op, args: []*checkNode{node.args[0], item}}
+		if expanded == nil {
+			expanded = cmp
+			continue
+		}
+		expanded = &checkNode{kind: kind, args: []*checkNode{expanded, cmp}}
+	}
+
+	return t.cond(expanded, negative)
+}
+
+// nullCond gets IS NULL or IS NOT NULL condition of operand
+func (o checkOperand) nullCond(notNull bool) string {
+	switch {
+	case len(o.isNull) > 0 && notNull:
+		return joinConds(" && ", o.notNull...)
+	case len(o.isNull) > 0:
+		return joinConds(" || ", o.isNull...)
+	case o.zero != "" && notNull:
+		return "!" + o.zero
+	case o.zero != "":
+		return o.zero
+	}
+	return strconv.FormatBool(notNull)
+}
+
+// atomCond gets condition of comparison which is NULL if any operand is NULL
+func atomCond(cond string, operands ...checkOperand) string {
+	var conds []string
+	seen := map[string]bool{}
+	for _, operand := range operands {
+		for _, notNull := range operand.notNull {
+			if !seen[notNull] {
+				seen[notNull] = true
+				conds = append(conds, notNull)
+			}
+		}
+	}
+	return joinConds(" && ", append(conds, cond)...)
+}
+
+// joinConds joins conditions wrapping ones with other operators in parentheses
+func joinConds(sep string, conds ...string) string {
+	other := " || "
+	if sep == other {
+		other = " && "
+	}
+	for i, cond := range conds {
+		if strings.Contains(cond, other) {
+			conds[i] = "(" + cond + ")"
+		}
+	}
+	return strings.Join(conds, sep)
+}
+
+// negatedOps are comparison operators with opposite result
+var negatedOps = map[string]string{
+	"=": "<>", "<>": "=", "!=": "=",
+	"<": ">=", ">=": "<", ">": "<=", "<=": ">",
+	"~": "!~", "!~": "~", "~*": "!~*", "!~*": "~*",
+	"~~": "!~~", "!~~": "~~", "~~*": "!~~*", "!~~*": "~~*",
+}
+
+func negateOp(op string) string {
+	if negated, ok := negatedOps[op]; ok {
+		return negated
+	}
+	return "NOT " + op
+}
+
+// compare translates comparison of operands
+func compare(op string, left, right checkOperand) (string, error) {
+	switch op {
+	case "~", "!~", "~*", "!~*", "~~", "!~~", "~~*", "!~~*":
+		if left.kind != kindString || right.kind != kindString || !right.constant {
+			return "", fmt.Errorf("operator %s is supported for string constant patterns only", op)
+		}
+
+		pattern := right.raw
+		if strings.HasPrefix(strings.TrimPrefix(op, "!"), "~~") {
+			pattern = likePattern(pattern)
+		}
+		if strings.HasSuffix(op, "*") {
+			pattern = "(?i)" + pattern
+		}
+		if _, err := regexp.Compile(pattern); err != nil {
+			return "", fmt.Errorf("pattern is not supported: %w", err)
+		}
+
+		cond := fmt.Sprintf("matches(%s, %s)", left.expr, strconv.Quote(pattern))
+		if strings.HasPrefix(op, "!") {
+			cond = "!" + cond
+		}
+		return cond, nil
+	}
+
+	goOp, ok := map[string]string{"=": "==", "<>": "!=", "!=": "!=", "<": "<", "<=": "<=", ">": ">", ">=": ">="}[op]
+	if !ok {
+		return "", fmt.Errorf("operator %s is not supported", op)
+	}
+	ordering := goOp != "==" && goOp != "!="
+
+	switch {
+	case left.kind == kindString && right.kind == kindString, left.kind == kindBool && right.kind == kindBool:
+		if ordering {
+			return "", fmt.Errorf("operator %s is not supported for %s", op, left.kind)
+		}
+	case isNumberKind(left.kind) && isNumberKind(right.kind):
+		// untyped integer constants fit any number type, others are compared as float64
+		switch {
+		case !left.constant && !right.constant && left.goType != right.goType:
+			left.expr, right.expr = "float64("+left.expr+")", "float64("+right.expr+")"
+		case left.constant && left.kind == kindFloat && right.kind == kindInt:
+			right.expr = "float64(" + right.expr + ")"
+		case right.constant && right.kind == kindFloat && left.kind == kindInt:
+			left.expr = "float64(" + left.expr + ")"
+		}
+	case left.kind == kindTime && right.kind == kindTime:
+		format := map[string]string{
+			"==": "%s.Equal(%s)", "!=": "!%s.Equal(%s)",
+			"<": "%s.Before(%s)", ">=": "!%s.Before(%s)",
+			">": "%s.After(%s)", "<=": "!%s.After(%s)",
+		}[goOp]
+		if strings.HasPrefix(left.expr, "*") {
+			left.expr = "(" + left.expr + ")"
+		}
+		return fmt.Sprintf(format, left.expr, right.expr), nil
+	default:
+		return "", fmt.Errorf("comparison of %s with %s is not supported", left.kind, right.kind)
+	}
+
+	return fmt.Sprintf("%s %s %s", left.expr, goOp, right.expr), nil
+}
+
+func isNumberKind(kind string) bool {
+	return kind == kindInt || kind == kindFloat
+}
+
+// likePattern converts LIKE pattern to regular expression
+func likePattern(pattern string) string {
+	var b strings.Builder
+	b.WriteString("^(?s)")
+	runes := []rune(pattern)
+	for i := 0; i < len(runes); i++ {
+		switch r := runes[i]; {
+		case r == '\\' && i+1 < len(runes):
+			i++
+			b.WriteString(regexp.QuoteMeta(string(runes[i])))
+		case r == '%':
+			b.WriteString(".*")
+		case r == '_':
+			b.WriteString(".")
+		default:
+			b.WriteString(regexp.QuoteMeta(string(r)))
+		}
+	}
+	b.WriteString("$")
+	return b.String()
+}
+
+// goKind gets operand kind of go type
+func goKind(goType string) (string, bool) {
+	switch goType {
+	case model.TypeString:
+		return kindString, true
+	case model.TypeInt, model.TypeInt32, model.TypeInt64, "int8", "int16", "uint", "uint8", "uint16", "uint32", "uint64":
+		return kindInt, true
+	case model.TypeFloat32, model.TypeFloat64:
+		return kindFloat, true
+	case model.TypeBool:
+		return kindBool, true
+	case model.TypeTime:
+		return kindTime, true
+	}
+	return "", false
+}
+
+// castKinds are operand kinds of cast types
+var castKinds = map[string]string{
+	"text": kindString, "character varying": kindString, "varchar": kindString, "character": kindString,
+	"bpchar": kindString, "name": kindString, "citext": kindString,
+	"integer": kindInt, "int": kindInt, "int2": kindInt, "int4": kindInt, "int8": kindInt, "smallint": kindInt, "bigint": kindInt,
+	"numeric": kindFloat, "decimal": kindFloat, "real": kindFloat, "double precision": kindFloat, "float4": kindFloat, "float8": kindFloat,
+	"boolean": kindBool, "bool": kindBool,
+}
+
+// operand translates value expression
+func (t *checkTranslator) operand(node *checkNode) (checkOperand, error) {
+	switch node.kind {
+	case nodeColumn, nodeValue:
+		var column TemplateColumn
+		if node.kind == nodeValue {
+			if t.value == nil {
+				return checkOperand{}, fmt.Errorf("VALUE is used outside of domain")
+			}
+			column = *t.value
+		} else {
+			var ok bool
+			if column, ok = t.columns[node.text]; !ok {
+				return checkOperand{}, fmt.Errorf("unknown column %s", node.text)
+			}
+			t.use(column)
+		}
+
+		guard, value, ok := checkValue(column)
+		kind, known := goKind(column.GoType)
+		if !ok || !known {
+			return checkOperand{}, fmt.Errorf("column %s of type %s is not supported", column.PGName, column.Type)
+		}
+
+		operand := checkOperand{expr: value, kind: kind, goType: column.GoType}
+		if guard = strings.TrimSuffix(guard, " && "); guard != "" {
+			operand.notNull = []string{guard}
+			operand.isNull = []string{nullOf(guard)}
+		} else if !column.IsPK {
+			operand.zero = fmt.Sprintf("isZero(m.%s)", column.GoName)
+		}
+		return operand, nil
+	case nodeNumber:
+		kind := kindInt
+		if strings.Contains(node.text, ".") {
+			kind = kindFloat
+		}
+		return checkOperand{expr: node.text, kind: kind, constant: true}, nil
+	case nodeString:
+		return checkOperand{expr: strconv.Quote(node.text), raw: node.text, kind: kindString, constant: true}, nil
+	case nodeBool:
+		return checkOperand{expr: node.text, kind: kindBool, constant: true}, nil
+	case nodeArray:
+		return checkOperand{kind: kindArray, items: node.args}, nil
+	case nodeCast:
+		return t.cast(node)
+	case nodeFunc:
+		return t.function(node)
+	}
+
+	return checkOperand{}, fmt.Errorf("value expression expected")
+}
+
+// cast translates cast of operand, only casts not changing go value are supported
+func (t *checkTranslator) cast(node *checkNode) (checkOperand, error) {
+	operand, err := t.operand(node.args[0])
+	if err != nil {
+		return checkOperand{}, err
+	}
+
+	if strings.HasSuffix(node.text, "[]") {
+		if operand.kind != kindArray {
+			return checkOperand{}, fmt.Errorf("cast to %s is not supported", node.text)
+		}
+		return operand, nil
+	}
+
+	kind, ok := castKinds[node.text]
+	switch {
+	case !ok:
+	case operand.kind == kind, isNumberKind(operand.kind) && isNumberKind(kind):
+		return operand, nil
+	case operand.constant && operand.kind == kindString && isNumberKind(kind):
+		if _, err := strconv.ParseFloat(operand.raw, 64); err != nil {
+			return checkOperand{}, fmt.Errorf("invalid number %s", operand.expr)
+		}
+		kind = kindInt
+		if strings.ContainsAny(operand.raw, ".eE") {
+			kind = kindFloat
+		}
+		return checkOperand{expr: operand.raw, kind: kind, constant: true}, nil
+	}
+
+	return checkOperand{}, fmt.Errorf("cast of %s to %s is not supported", operand.kind, node.text)
+}
+
+// function translates call of string functions and NULL counters
+func (t *checkTranslator) function(node *checkNode) (checkOperand, error) {
+	args := make([]checkOperand, len(node.args))
+	for i, arg := range node.args {
+		operand, err := t.operand(arg)
+		if err != nil {
+			return checkOperand{}, err
+		}
+		args[i] = operand
+	}
+
+	switch node.text {
+	case "num_nonnulls", "num_nulls":
+		conds := make([]string, len(args))
+		for i, arg := range args {
+			conds[i] = arg.nullCond(true)
+		}
+		expr := fmt.Sprintf("nonNulls(%s)", strings.Join(conds, ", "))
+		if node.text == "num_nulls" {
+			expr = fmt.Sprintf("%d - %s", len(args), expr)
+		}
+		return checkOperand{expr: expr, kind: kindInt, goType: model.TypeInt}, nil
+	}
+
+	helpers := map[string]struct{ format, kind string }{
+		"length":           {"charLength(%s)", kindInt},
+		"char_length":      {"charLength(%s)", kindInt},
+		"character_length": {"charLength(%s)", kindInt},
+		"octet_length":     {"len(%s)", kindInt},
+		"btrim":            {"trimSpaces(%s)", kindString},
+		"lower":            {"toLower(%s)", kindString},
+		"upper":            {"toUpper(%s)", kindString},
+	}
+	helper, ok := helpers[node.text]
+	if !ok || len(args) != 1 || args[0].kind != kindString {
+		return checkOperand{}, fmt.Errorf("function %s is not supported", node.text)
+	}
+
+	result := args[0]
+	result.expr = fmt.Sprintf(helper.format, result.expr)
+	result.kind = helper.kind
+	result.goType = map[string]string{kindInt: model.TypeInt, kindString: model.TypeString}[helper.kind]
+	return result, nil
+}
+
+// use adds column to list of columns referenced by constraint
+func (t *checkTranslator) use(column TemplateColumn) {
+	for _, used := range t.used {
+		if used.PGName == column.PGName {
+			return
+		}
+	}
+	t.used = append(t.used, column)
+}
+
+// nullOf gets condition opposite to not null guard
+func nullOf(guard string) string {
+	if strings.HasSuffix(guard, " != nil") {
+		return strings.TrimSuffix(guard, " != nil") + " == nil"
+	}
+	return "!" + guard
+}
@@ -0,0 +1,178 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/ant31/bungen/model"
+	"github.com/ant31/bungen/util"
+)
+
+func Test_checkTranslator_translate(t *testing.T) {
+	entity := model.NewEntity(util.PublicSchema, "products", []model.Column{
+		model.NewColumn("price", model.TypePGNumeric, true, false, false, 0, false, false, 0, nil, nil),
+		model.NewColumn("qty", model.TypePGInt4, false, false, false, 0, false, false, 0, nil, nil),
+		model.NewColumn("code", model.TypePGVarchar, false, false, false, 0, false, false, 3, nil, nil),
+		model.NewColumn("status", model.TypePGVarchar, true, false, false, 0, false, false, 0, nil, nil),
+		model.NewColumn("startsAt", model.TypePGTimestamptz, false, false, false, 0, false, false, 0, nil, nil),
+		model.NewColumn("endsAt", model.TypePGTimestamptz, true, false, false, 0, false, false, 0, nil, nil),
+		model.NewColumn("weight", model.TypePGInt8, true, false, false, 0, false, false, 0, nil, nil),
+		model.NewColumn("tags", model.TypePGText, true, false, true, 1, false, false, 0, nil, nil),
+	}, nil)
+	columns := NewTemplateEntity(entity, Options{}).Columns
+	index := map[string]TemplateColumn{}
+	for _, column := range columns {
+		index[column.PGName] = column
+	}
+
+	tests := []struct {
+		name       string
+		definition string
+		want       string
+		wantErr    bool
+	}{
+		{
+			name:       "Should translate comparison with nullable column",
+			definition: "CHECK ((price >= (0)::numeric))",
+			want:       "m.Price != nil && *m.Price < 0",
+		},
+		{
+			name:       "Should translate length",
+			definition: "CHECK ((length((code)::text) = 3))",
+			want:       "charLength(m.Code) != 3",
+		},
+		{
+			name:       "Should translate IN list",
+			definition: "CHECK (((status)::text = ANY ((ARRAY['a'::character varying, 'b'::character varying])::text[])))",
+			want:       `m.Status != nil && !oneOf(*m.Status, "a", "b")`,
+		},
+		{
+			name:       "Should translate integer IN list",
+			definition: "CHECK ((qty = ANY (ARRAY[1, 2])))",
+			want:       "m.Qty != 1 && m.Qty != 2",
+		},
+		{
+			name:       "Should translate regex",
+			definition: "CHECK (((code)::text ~* '^[a-z]+$'::text))",
+			want:       `!matches(m.Code, "(?i)^[a-z]+$")`,
+		},
+		{
+			name:       "Should translate LIKE",
+			definition: "CHECK (((code)::text ~~ 'A_%'::text))",
+			want:       `!matches(m.Code, "^(?s)A..*$")`,
+		},
+		{
+			name:       "Should translate expanded BETWEEN",
+			definition: "CHECK (((qty >= 1) AND (qty <= 10)))",
+			want:       "m.Qty < 1 || m.Qty > 10",
+		},
+		{
+			name:       "Should translate BETWEEN",
+			definition: "CHECK ((qty BETWEEN 1 AND 10))",
+			want:       "m.Qty < 1 || m.Qty > 10",
+		},
+		{
+			name:       "Should translate NOT NULL combination",
+			definition: "CHECK (((price IS NOT NULL) OR (weight IS NOT NULL)))",
+			want:       "m.Price == nil && m.Weight == nil",
+		},
+		{
+			name:       "Should translate num_nonnulls",
+			definition: "CHECK ((num_nonnulls(price, weight) = 1))",
+			want:       "nonNulls(m.Price != nil, m.Weight != nil) != 1",
+		},
+		{
+			name:       "Should translate NOT",
+			definition: "CHECK ((NOT ((price IS NULL) AND (status IS NULL))))",
+			want:       "m.Price == nil && m.Status == nil",
+		},
+		{
+			name:       "Should translate times comparison",
+			definition: `CHECK (("endsAt" > "startsAt"))`,
+			want:       "m.EndsAt != nil && !(*m.EndsAt).After(m.StartsAt)",
+		},
+		{
+			name:       "Should compare different number types as float64",
+			definition: "CHECK (((qty)::numeric < price))",
+			want:       "m.Price != nil && float64(m.Qty) >= float64(*m.Price)",
+		},
+		{
+			name:       "Should translate negative constant",
+			definition: "CHECK ((weight > '-1'::integer))",
+			want:       "m.Weight != nil && *m.Weight <= -1",
+		},
+		{
+			name:       "Should translate OR with nulls",
+			definition: "CHECK (((price > (0)::numeric) OR (weight > 0)))",
+			want:       "m.Price != nil && *m.Price <= 0 && m.Weight != nil && *m.Weight <= 0",
+		},
+		{
+			name:       "Should fail on functions",
+			definition: "CHECK ((\"startsAt\" > now()))",
+			wantErr:    true,
+		},
+		{
+			name:       "Should fail on string ordering",
+			definition: "CHECK (((code)::text > 'A'::text))",
+			wantErr:    true,
+		},
+		{
+			name:       "Should fail on arrays",
+			definition: "CHECK ((cardinality(tags) > 0))",
+			wantErr:    true,
+		},
+		{
+			name:       "Should fail on unsupported pattern",
+			definition: "CHECK (((code)::text ~ '(a)\\1'::text))",
+			wantErr:    true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			translator := &checkTranslator{columns: index}
+			got, err := translator.translate(tt.definition)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("translate() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("translate() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_newTemplateConstraintChecks(t *testing.T) {
+	domain := model.NewDomain("geo", "countryCode", model.TypePGVarchar, true, []string{"CHECK (((VALUE)::text ~ '^[A-Z]{3}$'::text))"})
+	code := model.NewColumn("code", model.TypePGVarchar, false, false, false, 0, false, false, 0, nil, nil)
+	code.Domain = &domain
+
+	entity := model.NewEntity("geo", "countries", []model.Column{
+		code,
+		model.NewColumn("population", model.TypePGInt8, false, false, false, 0, false, false, 0, nil, nil),
+	}, nil)
+	entity.AddConstraint(model.NewConstraint("countries_population_check", model.ConstraintCheck, []string{"population"}, "CHECK ((population > 0))"))
+	entity.AddConstraint(model.NewConstraint("countries_code_check", model.ConstraintCheck, []string{"code"}, "CHECK ((code <> all_codes()))"))
+	entity.AddConstraint(model.NewConstraint("countries_pkey", model.ConstraintPrimaryKey, []string{"code"}, "PRIMARY KEY (code)"))
+
+	templateEntity := NewTemplateEntity(entity, Options{})
+	checks, unchecked := newTemplateConstraintChecks(entity, templateEntity.Columns)
+
+	if len(checks) != 2 {
+		t.Fatalf("len(checks) = %v, want %v", len(checks), 2)
+	}
+	if checks[0].Column.PGName != "code" || checks[0].Message != `"violates check constraint countryCode"` {
+		t.Errorf("checks[0] = %v, want domain check of code", checks[0])
+	}
+	if checks[1].Column.PGName != "population" || checks[1].Cond != "m.Population <= 0" {
+		t.Errorf("checks[1] = %v, want population check", checks[1])
+	}
+
+	if len(unchecked) != 1 || unchecked[0].Name != "countries_code_check" {
+		t.Errorf("unchecked = %v, want countries_code_check", unchecked)
+	}
+
+	warnings := UntranslatedChecks([]model.Entity{entity}, Options{})
+	if len(warnings) != 1 {
+		t.Errorf("UntranslatedChecks() = %v, want 1 warning", warnings)
+	}
+}
@@ -0,0 +1,98 @@
+package model
+
+import (
+	"strings"
+
+	"github.com/ant31/bungen/model"
+	"github.com/ant31/bungen/util"
+)
+
+// TemplateConstraint stores constraint which violation is translated into sentinel error
+type TemplateConstraint struct {
+	// Var is a sentinel error name, e.g. ErrUserEmailUnique
+	Var  string
+	Name string
+	// Kind is a name of generated ConstraintKind constant, e.g. ConstraintUnique
+	Kind    string
+	Columns []string
+}
+
+// constraintKinds are generated ConstraintKind constants and sentinel suffixes of constraint types
+var constraintKinds = map[string]struct{ kind, suffix string }{
+	model.ConstraintPrimaryKey: {"ConstraintPK", "PK"},
+	model.ConstraintUnique:     {"ConstraintUnique", "Unique"},
+	model.ConstraintForeignKey: {"ConstraintFK", "FK"},
+	model.ConstraintCheck:      {"ConstraintCheck", "Check"},
+	model.ConstraintExclusion:  {"ConstraintExclusion", "Exclusion"},
+}
+
+// newTemplateConstraints generates sentinel errors for constraints and unique or primary indexes without constraint
+func newTemplateConstraints(entity model.Entity, columns []TemplateColumn) []TemplateConstraint {
+	byName := map[string]TemplateColumn{}
+	for _, column := range columns {
+		byName[column.PGName] = column
+	}
+
+	var (
+		result   []TemplateConstraint
+		reserved []string
+	)
+	names := util.NewSet()
+	add := func(name, typ string, cols []string) {
+		kind, ok := constraintKinds[typ]
+		if !ok || !names.Add(name) {
+			return
+		}
+
+		var goNames []string
+		for _, col := range cols {
+			if column, ok := byName[col]; ok {
+				goNames = append(goNames, column.GoName)
+			}
+		}
+		if len(goNames) == 0 || len(goNames) != len(cols) {
+			goNames = []string{constraintGoName(entity.PGName, name)}
+		}
+		if typ == model.ConstraintPrimaryKey {
+			goNames = nil
+		}
+
+		v := model.Safe("Err"+entity.GoName+strings.Join(goNames, "")+kind.suffix, reserved)
+		reserved = append(reserved, v)
+
+		result = append(result, TemplateConstraint{
+			Var:     v,
+			Name:    name,
+			Kind:    kind.kind,
+			Columns: cols,
+		})
+	}
+
+	for _, constraint := range entity.Constraints {
+		add(constraint.Name, constraint.Type, constraint.Columns)
+	}
+	// unique and primary indexes without constraint are reported by their names
+	for _, index := range entity.Indexes {
+		switch {
+		case index.IsPrimary:
+			add(index.Name, model.ConstraintPrimaryKey, index.Columns)
+		case index.IsUnique:
+			add(index.Name, model.ConstraintUnique, index.Columns)
+		}
+	}
+
+	return result
+}
+
+// constraintGoName gets name part of sentinel error from constraint name without table prefix and type suffix
+func constraintGoName(table, name string) string {
+	trimmed := strings.TrimPrefix(name, table+"_")
+	for _, suffix := range []string{"_pkey", "_key", "_fkey", "_check", "_excl", "_idx"} {
+		trimmed = strings.TrimSuffix(trimmed, suffix)
+	}
+	if trimmed == "" {
+		trimmed = name
+	}
+
+	return util.CamelCased(util.Sanitize(trimmed))
+}
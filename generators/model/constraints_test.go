@@ -0,0 +1,57 @@
+package model
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/ant31/bungen/model"
+	"github.com/ant31/bungen/util"
+)
+
+func Test_newTemplateConstraints(t *testing.T) {
+	entity := model.NewEntity(util.PublicSchema, "users", []model.Column{
+		model.NewColumn("userId", model.TypePGInt8, false, false, false, 0, true, false, 0, nil, nil),
+		model.NewColumn("email", model.TypePGVarchar, false, false, false, 0, false, false, 0, nil, nil),
+		model.NewColumn("countryId", model.TypePGInt4, true, false, false, 0, false, true, 0, nil, nil),
+	}, nil)
+	entity.AddConstraint(model.NewConstraint("users_pkey", model.ConstraintPrimaryKey, []string{"userId"}, ""))
+	entity.AddConstraint(model.NewConstraint("users_email_key", model.ConstraintUnique, []string{"email"}, ""))
+	entity.AddConstraint(model.NewConstraint("fk_user_country", model.ConstraintForeignKey, []string{"countryId"}, ""))
+	entity.AddConstraint(model.NewConstraint("users_email_check", model.ConstraintCheck, []string{"email"}, ""))
+	entity.AddConstraint(model.NewConstraint("users_email_length_check", model.ConstraintCheck, []string{"email"}, ""))
+	entity.AddConstraint(model.NewConstraint("users_total_check", model.ConstraintCheck, nil, ""))
+	entity.AddIndex(model.NewIndex("users_pkey", []string{"userId"}, true, true, "btree", false, false))
+	entity.AddIndex(model.NewIndex("users_email_key", []string{"email"}, true, false, "btree", false, false))
+	entity.AddIndex(model.NewIndex("users_lower_email_idx", nil, true, false, "btree", false, true))
+
+	constraints := NewTemplateEntity(entity, Options{}).Constraints
+
+	got := make([]string, len(constraints))
+	for i, constraint := range constraints {
+		got[i] = constraint.Var + " " + constraint.Kind + " " + constraint.Name
+	}
+	want := []string{
+		"ErrUserPK ConstraintPK users_pkey",
+		"ErrUserEmailUnique ConstraintUnique users_email_key",
+		"ErrUserCountryIDFK ConstraintFK fk_user_country",
+		"ErrUserEmailCheck ConstraintCheck users_email_check",
+		"ErrUserEmailCheck1 ConstraintCheck users_email_length_check",
+		"ErrUserTotalCheck ConstraintCheck users_total_check",
+		"ErrUserLowerEmailUnique ConstraintUnique users_lower_email_idx",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("newTemplateConstraints() = %#v, want %#v", got, want)
+	}
+}
+
+func Test_newTemplateConstraints_primaryIndex(t *testing.T) {
+	entity := model.NewEntity(util.PublicSchema, "users", []model.Column{
+		model.NewColumn("userId", model.TypePGInt8, false, false, false, 0, true, false, 0, nil, nil),
+	}, nil)
+	entity.AddIndex(model.NewIndex("users_pkey", []string{"userId"}, true, true, "btree", false, false))
+
+	constraints := NewTemplateEntity(entity, Options{}).Constraints
+	if len(constraints) != 1 || constraints[0].Var != "ErrUserPK" || constraints[0].Kind != "ConstraintPK" || constraints[0].Name != "users_pkey" {
+		t.Errorf("newTemplateConstraints() = %#v, want ErrUserPK", constraints)
+	}
+}
@@ -0,0 +1,56 @@
+package model
+
+import (
+	"github.com/ant31/bungen/model"
+)
+
+// encodings of COPY columns, names of constants in generated code
+const (
+	copyText   = "copyText"
+	copyArray  = "copyArray"
+	copyJSON   = "copyJSON"
+	copyHstore = "copyHstore"
+)
+
+// TemplateCopyColumn stores column written by COPY
+type TemplateCopyColumn struct {
+	TemplateColumn
+
+	// Kind is an encoding of column value in COPY text format
+	Kind string
+	// Defaulted column is left out of COPY batch if it's zero in all rows, so database default is used
+	Defaulted bool
+}
+
+// newTemplateCopyColumns gets columns written by COPY
+// generated columns are computed by database and unsupported ones are not mapped
+func newTemplateCopyColumns(columns []TemplateColumn) []TemplateCopyColumn {
+	var result []TemplateCopyColumn
+	for _, column := range columns {
+		if column.IsGenerated || column.GoType == model.TypeInterface {
+			continue
+		}
+
+		result = append(result, TemplateCopyColumn{
+			TemplateColumn: column,
+			Kind:           copyKind(column.Column),
+			Defaulted:      column.Default != "" || column.IsIdentity,
+		})
+	}
+
+	return result
+}
+
+// copyKind gets encoding of column by its postgres type
+func copyKind(column model.Column) string {
+	switch {
+	case column.IsArray:
+		return copyArray
+	case column.PGType == model.TypePGJSON || column.PGType == model.TypePGJSONB:
+		return copyJSON
+	case column.PGType == model.TypePGHstore:
+		return copyHstore
+	}
+
+	return copyText
+}
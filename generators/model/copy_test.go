@@ -0,0 +1,78 @@
+package model
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ant31/bungen/generators/model/templates"
+	"github.com/ant31/bungen/model"
+)
+
+func copyTestEntity() model.Entity {
+	id := model.NewColumn("id", model.TypePGInt8, false, false, false, 0, true, false, 0, nil, nil)
+	id.Default = "nextval('users_id_seq'::regclass)"
+	generated := model.NewColumn("search", model.TypePGText, true, false, false, 0, false, false, 0, nil, nil)
+	generated.IsGenerated = true
+
+	return model.NewEntity("auth", "users", []model.Column{
+		id,
+		model.NewColumn("email", model.TypePGText, false, false, false, 0, false, false, 0, nil, nil),
+		model.NewColumn("tags", model.TypePGText, true, false, true, 1, false, false, 0, nil, nil),
+		model.NewColumn("meta", model.TypePGJSONB, true, false, false, 0, false, false, 0, nil, nil),
+		model.NewColumn("attrs", model.TypePGHstore, true, false, false, 0, false, false, 0, nil, nil),
+		generated,
+	}, nil)
+}
+
+func Test_newTemplateCopyColumns(t *testing.T) {
+	columns := NewTemplateEntity(copyTestEntity(), Options{}).CopyColumns
+	result := make([]string, len(columns))
+	for i, column := range columns {
+		result[i] = column.PGName + " " + column.Kind
+		if column.Defaulted {
+			result[i] += " defaulted"
+		}
+	}
+
+	want := "id copyText defaulted; email copyText; tags copyArray; meta copyJSON; attrs copyHstore"
+	if got := strings.Join(result, "; "); got != want {
+		t.Errorf("newTemplateCopyColumns() = %s, want %s", got, want)
+	}
+}
+
+func TestCopyTemplates(t *testing.T) {
+	options := Options{Package: "model", WithCopy: true}
+	options.Def()
+
+	models := renderTemplate(t, templates.Model, []model.Entity{copyTestEntity()}, options)
+	for _, want := range []string{
+		"\t\"context\"\n",
+		"\t\"io\"\n",
+		"func CopyAuthUsers(ctx context.Context, conn bun.Conn, rows []*AuthUser) (int64, error) {\n\treturn copyFrom(ctx, conn, AuthUserT.Table.Name(), len(rows), []copyColumn{\n",
+		"\t\t{name: Columns.AuthUser.ID, kind: copyText, defaulted: true, value: func(i int) interface{} { return rows[i].ID }},\n",
+		"\t\t{name: Columns.AuthUser.Tags, kind: copyArray, value: func(i int) interface{} { return rows[i].Tags }},\n",
+		"\t\t{name: Columns.AuthUser.Attrs, kind: copyHstore, value: func(i int) interface{} { return rows[i].Attrs }},\n\t})\n",
+		"func CopyOutAuthUsers(ctx context.Context, conn bun.Conn, w io.Writer) (int64, error) {\n\treturn copyTo(ctx, conn, w, AuthUserT.Table.Name(), []string{Columns.AuthUser.ID, Columns.AuthUser.Email, Columns.AuthUser.Tags, Columns.AuthUser.Meta, Columns.AuthUser.Attrs})\n",
+	} {
+		if !strings.Contains(models, want) {
+			t.Errorf("generated models do not contain %q", want)
+		}
+	}
+
+	// generated columns are computed by database
+	if strings.Contains(models, "rows[i].Search") {
+		t.Errorf("generated models copy generated column")
+	}
+
+	helpers := renderTemplate(t, templates.Copy, []model.Entity{copyTestEntity()}, options)
+	for _, want := range []string{
+		"var CopyBatchSize = 10000",
+		"func copyFrom(ctx context.Context, conn bun.Conn, table string, count int, columns []copyColumn) (int64, error)",
+		`fmter.FormatQuery("COPY ? (?) FROM STDIN", bun.Ident(table), bun.In(copyIdents(batch)))`,
+		"func copyTo(ctx context.Context, conn bun.Conn, w io.Writer, table string, columns []string) (int64, error)",
+	} {
+		if !strings.Contains(helpers, want) {
+			t.Errorf("generated copy helpers do not contain %q", want)
+		}
+	}
+}
@@ -0,0 +1,313 @@
+package model
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/ant31/bungen/model"
+	"github.com/ant31/bungen/util"
+)
+
+const (
+	timestampsEmbed = "Timestamps"
+	sharedEmbed     = "Shared"
+)
+
+// TemplateEmbed stores struct with columns shared by several entities
+type TemplateEmbed struct {
+	Name     string
+	Columns  []TemplateColumn
+	Entities []string
+}
+
+// ResolveEmbeds moves columns shared by entities into embedded structs
+// structs listed in options.Embeds go first, then groups of columns found in at least options.EmbedShared entities
+// returns warnings for configured structs which are not embedded anywhere
+func ResolveEmbeds(entities []model.Entity, options Options) []string {
+	var warnings []string
+
+	names := make([]string, 0, len(options.Embeds))
+	for name := range options.Embeds {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	reserved := embedReserved(entities)
+	for _, name := range names {
+		if contains(reserved, name) {
+			warnings = append(warnings, fmt.Sprintf("embed: name %q is already used", name))
+			continue
+		}
+
+		columns := strings.Fields(options.Embeds[name])
+		if embedColumns(entities, name, columns, options) == 0 {
+			warnings = append(warnings, fmt.Sprintf("embed: %s columns %s are not shared by any table", name, strings.Join(columns, ", ")))
+			continue
+		}
+		reserved = append(reserved, name)
+	}
+
+	if options.EmbedShared < 2 {
+		return warnings
+	}
+
+	for _, group := range sharedColumns(entities, options) {
+		name := sharedEmbed
+		if group.timestamps {
+			name = timestampsEmbed
+		}
+		name = model.Safe(name, reserved)
+
+		if embedColumns(entities, name, group.columns, options) > 0 {
+			reserved = append(reserved, name)
+		}
+	}
+
+	return warnings
+}
+
+// embedReserved lists names embedded struct can not have
+func embedReserved(entities []model.Entity) []string {
+	reserved := append([]string{}, model.ReservedEntityNames...)
+	for _, entity := range entities {
+		reserved = append(reserved, entity.GoName)
+	}
+
+	return reserved
+}
+
+// embedColumns sets embed name to columns of every entity having all of them with the same definition
+// the first entity having columns is a reference for the others, returns number of entities with embedded struct
+func embedColumns(entities []model.Entity, name string, columns []string, options Options) int {
+	if len(columns) == 0 {
+		return 0
+	}
+
+	var reference []string
+	embedded := 0
+	for i, entity := range entities {
+		signatures, ok := embedSignatures(entity, columns, options)
+		if !ok || hasField(entity, name) {
+			continue
+		}
+
+		if reference == nil {
+			reference = signatures
+		} else if strings.Join(signatures, "\n") != strings.Join(reference, "\n") {
+			continue
+		}
+
+		for j, column := range entity.Columns {
+			if contains(columns, column.PGName) {
+				entities[i].Columns[j].Embed = name
+			}
+		}
+		embedded++
+	}
+
+	return embedded
+}
+
+// embedSignatures gets definitions of columns in entity in the same order, ok is false if any column can't be embedded
+func embedSignatures(entity model.Entity, columns []string, options Options) ([]string, bool) {
+	signatures := make([]string, len(columns))
+	for i, name := range columns {
+		found := false
+		for _, column := range entity.Columns {
+			if column.PGName != name {
+				continue
+			}
+			if !isEmbeddable(entity, column) {
+				return nil, false
+			}
+
+			signatures[i] = embedSignature(entity, column, options)
+			found = true
+		}
+
+		if !found {
+			return nil, false
+		}
+	}
+
+	return signatures, true
+}
+
+// isEmbeddable checks if column can be moved to embedded struct
+// columns of composite primary key are used in struct literals, so they are kept in models
+func isEmbeddable(entity model.Entity, column model.Column) bool {
+	return column.Embed == "" && !(column.IsPK && entity.HasMultiplePKs())
+}
+
+// embedSignature is a rendered field of column, shared columns must be rendered the same way in all entities
+func embedSignature(entity model.Entity, column model.Column, options Options) string {
+	templateColumn := NewTemplateColumn(entity, column, options)
+	return fmt.Sprintf("%s %s %s %s", templateColumn.GoName, templateColumn.Type, templateColumn.Tag, templateColumn.Comment)
+}
+
+// hasField checks if entity has column with the same go name as embedded struct
+func hasField(entity model.Entity, name string) bool {
+	for _, column := range entity.Columns {
+		if column.GoName == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+type sharedGroup struct {
+	columns    []string
+	timestamps bool
+}
+
+// sharedColumns groups columns having the same definition in the same set of at least options.EmbedShared entities
+func sharedColumns(entities []model.Entity, options Options) []sharedGroup {
+	var signatures []string
+	holders := map[string][]int{}
+	columns := map[string]model.Column{}
+	for i, entity := range entities {
+		for _, column := range entity.Columns {
+			if !isEmbeddable(entity, column) {
+				continue
+			}
+
+			signature := embedSignature(entity, column, options)
+			if _, ok := holders[signature]; !ok {
+				signatures = append(signatures, signature)
+				columns[signature] = column
+			}
+			holders[signature] = append(holders[signature], i)
+		}
+	}
+
+	var groups []sharedGroup
+	index := map[string]int{}
+	for _, signature := range signatures {
+		if len(holders[signature]) < options.EmbedShared {
+			continue
+		}
+
+		column := columns[signature]
+		timestamp := column.GoType == model.TypeTime && !column.IsArray
+
+		key := fmt.Sprint(holders[signature])
+		if i, ok := index[key]; ok {
+			groups[i].columns = append(groups[i].columns, column.PGName)
+			groups[i].timestamps = groups[i].timestamps && timestamp
+			continue
+		}
+
+		index[key] = len(groups)
+		groups = append(groups, sharedGroup{columns: []string{column.PGName}, timestamps: timestamp})
+	}
+
+	// single column is not worth a struct
+	result := groups[:0]
+	for _, group := range groups {
+		if len(group.columns) > 1 {
+			result = append(result, group)
+		}
+	}
+
+	return result
+}
+
+// packageEmbeds collects embedded structs of entities in order of appearance
+func packageEmbeds(models []TemplateEntity) ([]TemplateEmbed, []string) {
+	imports := util.NewSet()
+
+	var embeds []TemplateEmbed
+	index := map[string]int{}
+	for _, entity := range models {
+		for _, column := range entity.Columns {
+			if column.Embed == "" {
+				continue
+			}
+
+			i, ok := index[column.Embed]
+			if !ok {
+				i = len(embeds)
+				index[column.Embed] = i
+				embeds = append(embeds, TemplateEmbed{Name: column.Embed})
+			}
+
+			if !contains(embeds[i].Entities, entity.GoName) {
+				embeds[i].Entities = append(embeds[i].Entities, entity.GoName)
+			}
+			// fields are taken from the first entity, others have the same definition
+			if len(embeds[i].Entities) == 1 {
+				embeds[i].Columns = append(embeds[i].Columns, column)
+				if imp := column.Import; imp != "" && column.GoType != model.TypeInterface {
+					imports.Add(imp)
+				}
+			}
+		}
+	}
+
+	return embeds, imports.Elements()
+}
+
+// embeddedImports are imports of embedded columns types still used in model file by search structs, finders, pages and patches
+func embeddedImports(columns, pks []TemplateColumn, finders []TemplateFinder, pages []TemplatePage, patches []TemplatePatchColumn, options Options) []string {
+	imports := util.NewSet()
+	add := func(column TemplateColumn, search bool) {
+		imp := column.Import
+		if search && (imp == "database/sql" || imp == bunImport) {
+			imp = model.GoImport(column.PGType, false, false)
+		}
+		if column.Embed != "" && imp != "" && imp != bunImport && column.GoType != model.TypeInterface {
+			imports.Add(imp)
+		}
+	}
+
+	if options.WithSearch && !options.Relaxed {
+		for _, column := range columns {
+			add(column, true)
+		}
+	}
+
+	if options.WithORM {
+		for _, column := range pks {
+			add(column, false)
+		}
+		for _, finder := range finders {
+			for _, column := range finder.Columns {
+				add(column, false)
+			}
+		}
+		for _, page := range pages {
+			for _, column := range page.Columns {
+				add(column, false)
+			}
+		}
+		for _, column := range patches {
+			add(column.TemplateColumn, false)
+		}
+	}
+
+	return imports.Elements()
+}
+
+// entityEmbeds lists embedded structs of entity in order of columns
+func entityEmbeds(columns []TemplateColumn) []string {
+	var embeds []string
+	for _, column := range columns {
+		if column.Embed != "" && !contains(embeds, column.Embed) {
+			embeds = append(embeds, column.Embed)
+		}
+	}
+
+	return embeds
+}
+
+func contains(list []string, element string) bool {
+	for _, e := range list {
+		if e == element {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,180 @@
+package model
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/ant31/bungen/generators/model/templates"
+	"github.com/ant31/bungen/model"
+	"github.com/ant31/bungen/util"
+)
+
+func embedTestEntities(withEvents bool) []model.Entity {
+	timestamps := func() []model.Column {
+		return []model.Column{
+			model.NewColumn("created_at", model.TypePGTimestamptz, false, false, false, 0, false, false, 0, nil, nil),
+			model.NewColumn("updated_at", model.TypePGTimestamptz, true, false, false, 0, false, false, 0, nil, nil),
+		}
+	}
+
+	users := model.NewEntity(util.PublicSchema, "users", append([]model.Column{
+		model.NewColumn("userId", model.TypePGInt8, false, false, false, 0, true, false, 0, nil, nil),
+		model.NewColumn("email", model.TypePGVarchar, false, false, false, 0, false, false, 0, nil, nil),
+	}, timestamps()...), nil)
+	projects := model.NewEntity(util.PublicSchema, "projects", append([]model.Column{
+		model.NewColumn("code", model.TypePGText, false, false, false, 0, true, false, 0, nil, nil),
+	}, timestamps()...), nil)
+	logs := model.NewEntity(util.PublicSchema, "logs", []model.Column{
+		model.NewColumn("id", model.TypePGInt8, false, false, false, 0, true, false, 0, nil, nil),
+		model.NewColumn("message", model.TypePGText, false, false, false, 0, false, false, 0, nil, nil),
+	}, nil)
+	entities := []model.Entity{users, projects, logs}
+	if !withEvents {
+		return entities
+	}
+
+	// updated_at is not null here, so created_at and updated_at are shared by different entities
+	events := model.NewEntity(util.PublicSchema, "events", []model.Column{
+		model.NewColumn("id", model.TypePGInt8, false, false, false, 0, true, false, 0, nil, nil),
+		model.NewColumn("message", model.TypePGText, false, false, false, 0, false, false, 0, nil, nil),
+		model.NewColumn("created_at", model.TypePGTimestamptz, false, false, false, 0, false, false, 0, nil, nil),
+		model.NewColumn("updated_at", model.TypePGTimestamptz, false, false, false, 0, false, false, 0, nil, nil),
+	}, nil)
+
+	return append(entities, events)
+}
+
+func embedsOf(entities []model.Entity) map[string][]string {
+	result := map[string][]string{}
+	for _, entity := range entities {
+		for _, column := range entity.Columns {
+			if column.Embed != "" {
+				result[entity.PGName] = append(result[entity.PGName], column.Embed+"."+column.PGName)
+			}
+		}
+	}
+
+	return result
+}
+
+func TestResolveEmbeds(t *testing.T) {
+	tests := []struct {
+		name       string
+		withEvents bool
+		options    Options
+		want       map[string][]string
+		warnings   []string
+	}{
+		{
+			name:       "Should embed configured columns with the same definition",
+			withEvents: true,
+			options:    Options{Embeds: map[string]string{"Audit": "created_at updated_at"}},
+			want: map[string][]string{
+				"users":    {"Audit.created_at", "Audit.updated_at"},
+				"projects": {"Audit.created_at", "Audit.updated_at"},
+			},
+		},
+		{
+			name:    "Should detect shared timestamps",
+			options: Options{EmbedShared: 2},
+			want: map[string][]string{
+				"users":    {"Timestamps.created_at", "Timestamps.updated_at"},
+				"projects": {"Timestamps.created_at", "Timestamps.updated_at"},
+			},
+		},
+		{
+			name:       "Should detect shared columns with primary key",
+			withEvents: true,
+			options:    Options{EmbedShared: 2},
+			want: map[string][]string{
+				"logs":   {"Shared.id", "Shared.message"},
+				"events": {"Shared.id", "Shared.message"},
+			},
+		},
+		{
+			name:     "Should warn about configured columns which are not shared",
+			options:  Options{Embeds: map[string]string{"Project": "id", "Deletable": "deleted_at"}},
+			want:     map[string][]string{},
+			warnings: []string{"embed: Deletable columns deleted_at are not shared by any table", `embed: name "Project" is already used`},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			entities := embedTestEntities(tt.withEvents)
+			warnings := ResolveEmbeds(entities, tt.options)
+			if !reflect.DeepEqual(warnings, tt.warnings) {
+				t.Errorf("ResolveEmbeds() warnings = %#v, want %#v", warnings, tt.warnings)
+			}
+			if got := embedsOf(entities); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ResolveEmbeds() = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewTemplatePackage_Embeds(t *testing.T) {
+	options := Options{Package: "model", WithORM: true, EmbedShared: 2}
+	entities := embedTestEntities(false)
+	ResolveEmbeds(entities, options)
+
+	pack := NewTemplatePackage(entities, options)
+	if len(pack.Embeds) != 1 || pack.Embeds[0].Name != "Timestamps" || len(pack.Embeds[0].Columns) != 2 {
+		t.Fatalf("NewTemplatePackage() embeds = %#v", pack.Embeds)
+	}
+	if got := strings.Join(pack.Embeds[0].Entities, ", "); got != "User, Project" {
+		t.Errorf("NewTemplatePackage() embed entities = %s", got)
+	}
+	if !reflect.DeepEqual(pack.EmbedImports, []string{"time"}) {
+		t.Errorf("NewTemplatePackage() embed imports = %#v", pack.EmbedImports)
+	}
+	if !reflect.DeepEqual(pack.Entities[0].Embeds, []string{"Timestamps"}) {
+		t.Errorf("NewTemplatePackage() entity embeds = %#v", pack.Entities[0].Embeds)
+	}
+	// patch struct has fields for embedded columns
+	if !reflect.DeepEqual(pack.Entities[0].Imports, []string{"time"}) {
+		t.Errorf("NewTemplatePackage() entity imports with orm = %#v", pack.Entities[0].Imports)
+	}
+
+	options.WithORM = false
+	pack = NewTemplatePackage(entities, options)
+	// time is used only by embedded struct
+	if len(pack.Entities[0].Imports) != 0 {
+		t.Errorf("NewTemplatePackage() entity imports = %#v", pack.Entities[0].Imports)
+	}
+
+	options.WithSearch = true
+	pack = NewTemplatePackage(entities, options)
+	// search struct has fields for embedded columns
+	if !reflect.DeepEqual(pack.Entities[0].Imports, []string{"fmt", "net/url", "strings", "time"}) {
+		t.Errorf("NewTemplatePackage() entity imports with search = %#v", pack.Entities[0].Imports)
+	}
+}
+
+func TestEmbedTemplates(t *testing.T) {
+	options := Options{Package: "model", WithORM: true, DBWrapName: "DBWrap", Embeds: map[string]string{"Keyed": "id message"}, EmbedShared: 2}
+	entities := embedTestEntities(false)
+	ResolveEmbeds(entities, options)
+
+	embeds := renderTemplate(t, templates.Embeds, entities, options)
+	for _, want := range []string{
+		"// Keyed contains columns shared by Log\ntype Keyed struct {",
+		"// Timestamps contains columns shared by User, Project\ntype Timestamps struct {",
+		"CreatedAt time.Time  `bun:\"created_at,nullzero\"`",
+	} {
+		if !strings.Contains(embeds, want) {
+			t.Errorf("generated embeds do not contain %q\n%s", want, embeds)
+		}
+	}
+
+	models := renderTemplate(t, templates.Model, entities, options)
+	for _, want := range []string{
+		"\tbun.BaseModel `bun:\"logs,alias:t\"`\n\n\tKeyed\n}",
+		"m := &Log{Keyed: Keyed{ID: pk}}",
+		"m := &User{ID: pk}",
+	} {
+		if !strings.Contains(models, want) {
+			t.Errorf("generated models do not contain %q", want)
+		}
+	}
+}
@@ -0,0 +1,106 @@
+package model
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/ant31/bungen/model"
+	"github.com/ant31/bungen/util"
+)
+
+// paramReserved are names used by generated repository methods
+var paramReserved = []string{"ctx", "db", "q", "m", "list", "relations", "pk", "pks", "pager", "search", "err",
+	"cursor", "limit", "direction", "page", "values", "more", "first", "last"}
+
+// TemplateFinder stores lookup method generated from index
+type TemplateFinder struct {
+	// Name is a method name, e.g. GetByEmail or ListByCountryID
+	Name    string
+	Index   string
+	Unique  bool
+	Columns []TemplateColumn
+}
+
+// newTemplateFinders generates GetBy<Cols> for unique indexes
+// and ListBy<Cols> for btree index prefixes which are not unique
+func newTemplateFinders(entity model.Entity, columns []TemplateColumn) []TemplateFinder {
+	byName := map[string]TemplateColumn{}
+	for _, column := range columns {
+		byName[column.PGName] = column
+	}
+
+	lookup := func(names []string) ([]TemplateColumn, bool) {
+		result := make([]TemplateColumn, len(names))
+		for i, name := range names {
+			column, ok := byName[name]
+			if !ok || !column.IsComparable() {
+				return nil, false
+			}
+			result[i] = column
+		}
+		return result, true
+	}
+
+	// column sets which identify a single row
+	unique := util.NewSet()
+	var pks []string
+	for _, column := range entity.GetPKs() {
+		pks = append(pks, column.PGName)
+	}
+	unique.Add(columnSet(pks))
+	for _, index := range entity.UniqueIndexes() {
+		unique.Add(columnSet(index.Columns))
+	}
+
+	var finders []TemplateFinder
+	names := util.NewSet()
+	add := func(prefix string, index model.Index, cols []TemplateColumn) {
+		goNames := make([]string, len(cols))
+		for i, column := range cols {
+			goNames[i] = column.GoName
+		}
+
+		name := prefix + strings.Join(goNames, "And")
+		if names.Add(name) {
+			finders = append(finders, TemplateFinder{
+				Name:    name,
+				Index:   index.Name,
+				Unique:  prefix == "GetBy",
+				Columns: cols,
+			})
+		}
+	}
+
+	for _, index := range entity.UniqueIndexes() {
+		if columnSet(index.Columns) == columnSet(pks) {
+			continue
+		}
+		if cols, ok := lookup(index.Columns); ok {
+			add("GetBy", index, cols)
+		}
+	}
+
+	for _, index := range entity.Indexes {
+		if !index.IsPlain() || !index.IsBtree() {
+			continue
+		}
+
+		for n := 1; n <= len(index.Columns); n++ {
+			if unique.Exists(columnSet(index.Columns[:n])) {
+				continue
+			}
+			if cols, ok := lookup(index.Columns[:n]); ok {
+				add("ListBy", index, cols)
+			}
+		}
+	}
+
+	return finders
+}
+
+// columnSet makes order independent key for columns
+func columnSet(columns []string) string {
+	sorted := append([]string{}, columns...)
+	sort.Strings(sorted)
+	return strings.Join(sorted, ",")
+}
@@ -0,0 +1,44 @@
+package model
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/ant31/bungen/model"
+	"github.com/ant31/bungen/util"
+)
+
+func Test_newTemplateFinders(t *testing.T) {
+	columns := []model.Column{
+		model.NewColumn("userId", model.TypePGInt8, false, false, false, 0, true, false, 0, nil, nil),
+		model.NewColumn("email", model.TypePGVarchar, false, false, false, 0, false, false, 0, nil, nil),
+		model.NewColumn("tenantId", model.TypePGInt8, false, false, false, 0, false, false, 0, nil, nil),
+		model.NewColumn("name", model.TypePGText, true, false, false, 0, false, false, 0, nil, nil),
+		model.NewColumn("tags", model.TypePGText, true, false, true, 1, false, false, 0, nil, nil),
+	}
+
+	entity := model.NewEntity(util.PublicSchema, "users", columns, nil)
+	entity.AddIndex(model.NewIndex("users_pkey", []string{"userId"}, true, true, "btree", false, false))
+	entity.AddIndex(model.NewIndex("users_tenant_email_key", []string{"tenantId", "email"}, true, false, "btree", false, false))
+	entity.AddIndex(model.NewIndex("users_tenant_name_idx", []string{"tenantId", "name"}, false, false, "btree", false, false))
+	entity.AddIndex(model.NewIndex("users_email_partial_key", []string{"email"}, true, false, "btree", true, false))
+	entity.AddIndex(model.NewIndex("users_tags_idx", []string{"tags"}, false, false, "gin", false, false))
+	entity.AddIndex(model.NewIndex("users_name_hash_idx", []string{"name"}, false, false, "hash", false, false))
+
+	finders := NewTemplateEntity(entity, Options{}).Finders
+
+	names := make([]string, len(finders))
+	for i, finder := range finders {
+		names[i] = finder.Name
+	}
+
+	want := []string{"GetByTenantIDAndEmail", "ListByTenantID", "ListByTenantIDAndName"}
+	if !reflect.DeepEqual(names, want) {
+		t.Errorf("newTemplateFinders() = %v, want %v", names, want)
+	}
+
+	params := []string{finders[0].Columns[0].ParamName, finders[0].Columns[0].ParamType}
+	if want := []string{"tenantID", model.TypeInt64}; !reflect.DeepEqual(params, want) {
+		t.Errorf("newTemplateFinders()[0].Columns[0] params = %v, want %v", params, want)
+	}
+}
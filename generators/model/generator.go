@@ -1,6 +1,8 @@
 package model
 
 import (
+	"fmt"
+	"log"
 	"path/filepath"
 	"strings"
 
@@ -58,18 +60,11 @@ func (g *Basic) ReadFlags(command *cobra.Command) error {
 	return nil
 }
 
-func (g *Basic) genPerEntities(name string, tpl string, fileExt string) error {
+// GeneratePerEntity generates file for every entity, file name is a lower cased entity name with fileExt and .gen.go suffixes
+func (g *Basic) GeneratePerEntity(entities []model.Entity, name string, tpl string, fileExt string) error {
 	gen := base.NewGenerator(g.options.URL, name)
-	entities, err := gen.Read(g.options.Tables,
-		g.options.FollowFKs,
-		g.options.UseSQLNulls,
-		g.options.CustomTypes)
-	if err != nil {
-		return err
-	}
-
 	for i, ent := range entities {
-		err = gen.GenerateFromEntities(entities[i:i+1],
+		err := gen.GenerateFromEntities(entities[i:i+1],
 			filepath.Join(g.options.Output,
 				strings.ToLower(ent.GoName))+fileExt+".gen.go",
 			tpl,
@@ -81,30 +76,103 @@ func (g *Basic) genPerEntities(name string, tpl string, fileExt string) error {
 	return nil
 }
 
-func (g *Basic) genOnce(name string, tpl string, filename string) error {
-	gen := base.NewGenerator(g.options.URL, "Tables")
-	return gen.Generate(
-		g.options.Tables,
-		g.options.FollowFKs,
-		g.options.UseSQLNulls,
+// GenerateOnce generates one file for all entities
+func (g *Basic) GenerateOnce(entities []model.Entity, name string, tpl string, filename string) error {
+	gen := base.NewGenerator(g.options.URL, name)
+	return gen.GenerateFromEntities(entities,
 		filepath.Join(g.options.Output, filename),
 		tpl,
 		g.Packer(),
-		g.options.CustomTypes,
 	)
 }
 
+// Read reads entities from database, reports unused overrides, rejects conflicting field names and untranslated checks, resolves json types and embedded structs
+func (g *Basic) Read() ([]model.Entity, error) {
+	model.SetNamingStrategy(g.options.Naming())
+
+	gen := base.NewGenerator(g.options.URL, "Tables")
+	entities, err := gen.Read(g.options.Tables,
+		g.options.FollowFKs,
+		g.options.UseSQLNulls,
+		g.options.CustomTypes)
+	if err != nil {
+		return nil, fmt.Errorf("read database error: %w", err)
+	}
+
+	for _, warning := range UnmatchedOverrides(entities, g.options) {
+		log.Printf("warning: %s", warning)
+	}
+	if conflicts := FieldNameConflicts(entities, g.options); len(conflicts) > 0 {
+		return nil, fmt.Errorf("field name conflicts: %s", strings.Join(conflicts, "; "))
+	}
+	if g.options.WithValidation {
+		for _, warning := range UntranslatedChecks(entities, g.options) {
+			log.Printf("warning: %s", warning)
+		}
+	}
+
+	if err = ResolveJSONTypes(entities, g.options, gen.SampleJSON); err != nil {
+		return nil, err
+	}
+
+	for _, warning := range ResolveEmbeds(entities, g.options) {
+		log.Printf("warning: %s", warning)
+	}
+
+	return entities, nil
+}
+
 // Generate runs whole generation process
 func (g *Basic) Generate() error {
+	entities, err := g.Read()
+	if err != nil {
+		return err
+	}
+
+	err = g.GenerateOnce(entities, "Tables", templates.Tables, "tables.gen.go")
+	if err != nil {
+		return err
+	}
 
-	err := g.genOnce("Tables", templates.Tables, "tables.gen.go")
+	err = g.GenerateTypes(entities)
 	if err != nil {
 		return err
 	}
+
+	pack := NewTemplatePackage(entities, g.options)
+	if len(pack.Embeds) > 0 {
+		err = g.GenerateOnce(entities, "Embeds", templates.Embeds, "embeds.gen.go")
+		if err != nil {
+			return err
+		}
+	}
+
+	if pack.HasAudit {
+		err = g.GenerateOnce(entities, "Hooks", templates.Hooks, "hooks.gen.go")
+		if err != nil {
+			return err
+		}
+	}
+
+	if pack.HasConstraints {
+		err = g.GenerateOnce(entities, "Errors", templates.Errors, "errors.gen.go")
+		if err != nil {
+			return err
+		}
+	}
+
 	e := ""
 	if g.options.WithSearch {
 		e += " +search"
-		err := g.genOnce("Search", templates.Search, "search.gen.go")
+		err := g.GenerateOnce(entities, "Search", templates.Search, "search.gen.go")
+		if err != nil {
+			return err
+		}
+	}
+
+	if g.options.WithValidation {
+		e += " +validation"
+		err := g.GenerateOnce(entities, "Validation", templates.Validation, "validation.gen.go")
 		if err != nil {
 			return err
 		}
@@ -112,13 +180,21 @@ func (g *Basic) Generate() error {
 
 	if g.options.WithORM {
 		e += " +orm"
-		err := g.genOnce("ORM", templates.ORM, "orm.gen.go")
+		err := g.GenerateOnce(entities, "ORM", templates.ORM, "orm.gen.go")
 		if err != nil {
 			return err
 		}
 	}
 
-	err = g.genPerEntities("Models"+e, templates.Model, ".model")
+	if g.options.WithCopy {
+		e += " +copy"
+		err := g.GenerateOnce(entities, "Copy", templates.Copy, "copy.gen.go")
+		if err != nil {
+			return err
+		}
+	}
+
+	err = g.GeneratePerEntity(entities, "Models"+e, templates.Model, ".model")
 	if err != nil {
 		return err
 	}
@@ -127,6 +203,33 @@ func (g *Basic) Generate() error {
 
 }
 
+// GenerateTypes generates files with composite, network and json types used by columns of entities
+func (g *Basic) GenerateTypes(entities []model.Entity) error {
+	pack := NewTemplatePackage(entities, g.options)
+	if len(pack.Composites) > 0 {
+		err := g.GenerateOnce(entities, "Types", templates.Types, "types.gen.go")
+		if err != nil {
+			return err
+		}
+	}
+
+	if pack.HasNetIP {
+		err := g.GenerateOnce(entities, "Net", templates.Net, "net.gen.go")
+		if err != nil {
+			return err
+		}
+	}
+
+	if len(pack.JSONStructs) > 0 {
+		err := g.GenerateOnce(entities, "JSON", templates.JSON, "json.gen.go")
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 // Packer returns packer function for compile entities into package
 func (g *Basic) Packer() base.Packer {
 	return func(entities []model.Entity) (interface{}, error) {
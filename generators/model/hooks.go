@@ -0,0 +1,146 @@
+package model
+
+import (
+	"fmt"
+	"html/template"
+	"strings"
+
+	"github.com/ant31/bungen/model"
+)
+
+// TemplateHook stores columns filled by BeforeAppendModel hook of entity
+type TemplateHook struct {
+	// CreatedAt are set to current time on insert if empty, UpdatedAt on insert and update
+	CreatedAt []TemplateHookColumn
+	UpdatedAt []TemplateHookColumn
+	// CreatedBy are set to user from context on insert, UpdatedBy on insert and update
+	CreatedBy []TemplateHookColumn
+	UpdatedBy []TemplateHookColumn
+	// UserType is a type of user value in context
+	UserType string
+}
+
+// TemplateHookColumn stores column filled by hook
+type TemplateHookColumn struct {
+	TemplateColumn
+
+	// Value is assigned to column, it uses now or user variables
+	Value template.HTML
+	// Empty checks if column is not set
+	Empty template.HTML
+}
+
+// HasTimes checks if current time is used on insert
+func (h TemplateHook) HasTimes() bool {
+	return len(h.CreatedAt) > 0 || len(h.UpdatedAt) > 0
+}
+
+// HasUsers checks if user from context is used on insert
+func (h TemplateHook) HasUsers() bool {
+	return len(h.CreatedBy) > 0 || len(h.UpdatedBy) > 0
+}
+
+// HasUpdate checks if any column is filled on update
+func (h TemplateHook) HasUpdate() bool {
+	return len(h.UpdatedAt) > 0 || len(h.UpdatedBy) > 0
+}
+
+// UpdateColumns gets columns filled on update, they are added to column list of update queries
+func (h TemplateHook) UpdateColumns() []TemplateHookColumn {
+	return append(append([]TemplateHookColumn{}, h.UpdatedAt...), h.UpdatedBy...)
+}
+
+// newTemplateHook finds conventional timestamp and audit columns of entity, returns nil if there are none
+func newTemplateHook(columns []TemplateColumn, options Options) *TemplateHook {
+	if !options.WithHooks {
+		return nil
+	}
+
+	hook := TemplateHook{}
+	for _, column := range columns {
+		if column.IsGenerated || column.IsArray {
+			continue
+		}
+
+		if isTimeColumn(column) {
+			value, empty, ok := hookTimeValue(column)
+			if !ok {
+				continue
+			}
+			hookColumn := TemplateHookColumn{TemplateColumn: column, Value: template.HTML(value), Empty: template.HTML(empty)}
+
+			if contains(options.CreatedAt, column.PGName) {
+				hook.CreatedAt = append(hook.CreatedAt, hookColumn)
+			} else if contains(options.UpdatedAt, column.PGName) {
+				hook.UpdatedAt = append(hook.UpdatedAt, hookColumn)
+			}
+		}
+
+		created, updated := contains(options.CreatedBy, column.PGName), contains(options.UpdatedBy, column.PGName)
+		if !created && !updated {
+			continue
+		}
+		// all audit columns hold the same user value
+		if hook.UserType != "" && hook.UserType != column.GoType {
+			continue
+		}
+		value, ok := hookUserValue(column)
+		if !ok {
+			continue
+		}
+
+		hook.UserType = column.GoType
+		hookColumn := TemplateHookColumn{TemplateColumn: column, Value: template.HTML(value)}
+		if created {
+			hook.CreatedBy = append(hook.CreatedBy, hookColumn)
+		} else {
+			hook.UpdatedBy = append(hook.UpdatedBy, hookColumn)
+		}
+	}
+
+	if !hook.HasTimes() && !hook.HasUsers() {
+		return nil
+	}
+
+	return &hook
+}
+
+func isTimeColumn(column TemplateColumn) bool {
+	return column.GoType == model.TypeTime
+}
+
+// hookTimeValue gets expression of current time assigned to column and condition of empty column
+func hookTimeValue(column TemplateColumn) (value, empty string, ok bool) {
+	field := "m." + column.GoName
+
+	switch column.Type {
+	case column.GoType:
+		return "now", field + ".IsZero()", true
+	case "*" + column.GoType:
+		return "&now", field + " == nil", true
+	case "bun.NullTime":
+		return "bun.NullTime{Time: now}", field + ".IsZero()", true
+	}
+
+	return "", "", false
+}
+
+// hookUserValue gets expression of user from context assigned to column
+func hookUserValue(column TemplateColumn) (string, bool) {
+	if column.GoType == model.TypeInterface {
+		return "", false
+	}
+
+	switch column.Type {
+	case column.GoType:
+		return "user", true
+	case "*" + column.GoType:
+		return "&user", true
+	}
+
+	if name, ok := sqlNullValues[column.Type]; ok {
+		return fmt.Sprintf("%s{%s: %s(user), Valid: true}", column.Type, name, strings.ToLower(name)), true
+	}
+
+	return "", false
+}
@@ -0,0 +1,100 @@
+package model
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ant31/bungen/generators/model/templates"
+	"github.com/ant31/bungen/model"
+	"github.com/ant31/bungen/util"
+)
+
+func hookTestEntity() model.Entity {
+	generated := model.NewColumn("changed_at", model.TypePGTimestamptz, true, false, false, 0, false, false, 0, nil, nil)
+	generated.IsGenerated = true
+
+	return model.NewEntity(util.PublicSchema, "users", []model.Column{
+		model.NewColumn("userId", model.TypePGInt8, false, false, false, 0, true, false, 0, nil, nil),
+		model.NewColumn("created_at", model.TypePGTimestamptz, false, false, false, 0, false, false, 0, nil, nil),
+		model.NewColumn("updated_at", model.TypePGTimestamptz, true, false, false, 0, false, false, 0, nil, nil),
+		model.NewColumn("modified_at", model.TypePGTimestamptz, true, true, false, 0, false, false, 0, nil, nil),
+		model.NewColumn("created_by", model.TypePGInt8, false, false, false, 0, false, false, 0, nil, nil),
+		model.NewColumn("updated_by", model.TypePGInt8, true, true, false, 0, false, false, 0, nil, nil),
+		model.NewColumn("reviewed_by", model.TypePGText, true, false, false, 0, false, false, 0, nil, nil),
+		generated,
+	}, nil)
+}
+
+func Test_newTemplateHook(t *testing.T) {
+	options := Options{
+		WithHooks: true,
+		CreatedAt: []string{"created_at"},
+		UpdatedAt: []string{"updated_at", "modified_at", "changed_at"},
+		CreatedBy: []string{"created_by"},
+		UpdatedBy: []string{"updated_by", "reviewed_by"},
+	}
+
+	hook := NewTemplateEntity(hookTestEntity(), options).Hook
+	if hook == nil {
+		t.Fatal("newTemplateHook() = nil")
+	}
+
+	render := func(columns []TemplateHookColumn) string {
+		result := make([]string, len(columns))
+		for i, column := range columns {
+			result[i] = column.GoName + " = " + string(column.Value)
+		}
+		return strings.Join(result, "; ")
+	}
+
+	tests := []struct {
+		name string
+		got  string
+		want string
+	}{
+		{name: "CreatedAt", got: render(hook.CreatedAt), want: "CreatedAt = now"},
+		{name: "UpdatedAt", got: render(hook.UpdatedAt), want: "UpdatedAt = &now; ModifiedAt = bun.NullTime{Time: now}"},
+		{name: "CreatedBy", got: render(hook.CreatedBy), want: "CreatedBy = user"},
+		// reviewed_by is a string, it can't hold the same user as other columns
+		{name: "UpdatedBy", got: render(hook.UpdatedBy), want: "UpdatedBy = sql.NullInt64{Int64: int64(user), Valid: true}"},
+		{name: "UserType", got: hook.UserType, want: "int64"},
+		{name: "Empty", got: string(hook.CreatedAt[0].Empty), want: "m.CreatedAt.IsZero()"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.got != tt.want {
+				t.Errorf("newTemplateHook() %s = %q, want %q", tt.name, tt.got, tt.want)
+			}
+		})
+	}
+
+	if hook := NewTemplateEntity(hookTestEntity(), Options{}).Hook; hook != nil {
+		t.Errorf("newTemplateHook() without hooks = %#v, want nil", hook)
+	}
+}
+
+func TestHookTemplates(t *testing.T) {
+	options := Options{Package: "model", WithHooks: true, AuditKey: "github.com/acme/auth.UserKey"}
+	options.Def()
+	entities := []model.Entity{hookTestEntity()}
+
+	hooks := renderTemplate(t, templates.Hooks, entities, options)
+	for _, want := range []string{`"github.com/acme/auth"`, "var AuditKey interface{} = auth.UserKey"} {
+		if !strings.Contains(hooks, want) {
+			t.Errorf("generated hooks do not contain %q\n%s", want, hooks)
+		}
+	}
+
+	models := renderTemplate(t, templates.Model, entities, options)
+	for _, want := range []string{
+		"func (m *User) BeforeAppendModel(ctx context.Context, query bun.Query) error {",
+		"\tcase *bun.InsertQuery:\n\t\tnow := time.Now()\n\t\tif m.CreatedAt.IsZero() {\n\t\t\tm.CreatedAt = now\n\t\t}\n\t\tm.UpdatedAt = &now\n",
+		"\tcase *bun.UpdateQuery:\n\t\tnow := time.Now()\n\t\tm.UpdatedAt = &now\n\t\tif user, ok := ctx.Value(AuditKey).(int64); ok {\n\t\t\tm.UpdatedBy = sql.NullInt64{Int64: int64(user), Valid: true}\n\t\t}\n",
+		`"context"`,
+		`"time"`,
+	} {
+		if !strings.Contains(models, want) {
+			t.Errorf("generated models do not contain %q\n%s", want, models)
+		}
+	}
+}
@@ -0,0 +1,185 @@
+package model
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"reflect"
+	"regexp"
+	"strings"
+
+	"github.com/ant31/bungen/model"
+	"github.com/ant31/bungen/util"
+)
+
+// jsonSampler reads values of json column
+type jsonSampler func(schema, table, column string, limit int) ([][]byte, error)
+
+// ResolveJSONTypes generates go structs for json columns
+// JSON Schema file is used first, then JSON Schema in column comment, then sampled rows
+func ResolveJSONTypes(entities []model.Entity, options Options, sample jsonSampler) error {
+	if !options.JSONStructs {
+		return nil
+	}
+
+	for i, entity := range entities {
+		for j, column := range entity.Columns {
+			if column.PGType != model.TypePGJSON && column.PGType != model.TypePGJSONB || column.IsArray {
+				continue
+			}
+
+			// explicit json type wins over generated structs
+			if typ, ok := columnOverride(options.JSONTypes, entity.PGSchema, entity.PGName, column.PGName); ok && typ != options.JSONTypes["*"] {
+				continue
+			}
+
+			typ, err := resolveJSONType(entity, column, options, sample)
+			if err != nil {
+				return fmt.Errorf("json type for %s.%s: %w", entity.PGFullName, column.PGName, err)
+			}
+
+			entities[i].Columns[j].JSONType = typ
+		}
+	}
+
+	uniqueJSONStructs(entities)
+
+	return nil
+}
+
+// identifierRe matches go identifiers in types of json fields
+var identifierRe = regexp.MustCompile(`[A-Za-z_][A-Za-z0-9_]*`)
+
+// uniqueJSONStructs renames structs of json columns which names are taken by entities, composites
+// or different structs of other columns, equal structs of different columns are shared
+func uniqueJSONStructs(entities []model.Entity) {
+	names := util.NewIndex()
+	for _, name := range model.ReservedEntityNames {
+		names.Add(name)
+	}
+	for _, entity := range entities {
+		names.Add(entity.GoName)
+		for _, identifier := range model.EntityIdentifiers(entity.GoName, entity.GoNamePlural) {
+			names.Add(identifier)
+		}
+		for _, column := range entity.Columns {
+			if column.Composite != nil {
+				names.Add(column.Composite.GoName)
+			}
+		}
+	}
+
+	structs := map[string][]model.JSONField{}
+	for i := range entities {
+		for j, column := range entities[i].Columns {
+			if column.JSONType == nil {
+				continue
+			}
+
+			// nested structs go first, so renames of their names are applied to fields of outer ones
+			renames := map[string]string{}
+			for _, s := range column.JSONType.Structs {
+				fields := renameJSONFields(s.Fields, renames)
+				if existing, ok := structs[s.GoName]; ok && reflect.DeepEqual(existing, fields) {
+					continue
+				} else if !ok && names.Available(s.GoName) {
+					names.Add(s.GoName)
+					structs[s.GoName] = fields
+					continue
+				}
+
+				name := names.GetNext(s.GoName)
+				names.Add(name)
+				renames[s.GoName] = name
+				structs[name] = renameJSONFields(s.Fields, renames)
+			}
+
+			if len(renames) == 0 {
+				continue
+			}
+
+			typ := *column.JSONType
+			typ.Type = renameJSONType(typ.Type, renames)
+			typ.Structs = make([]model.JSONStruct, len(column.JSONType.Structs))
+			for k, s := range column.JSONType.Structs {
+				typ.Structs[k] = model.JSONStruct{GoName: renameJSONType(s.GoName, renames), Fields: renameJSONFields(s.Fields, renames)}
+			}
+			entities[i].Columns[j].JSONType = &typ
+		}
+	}
+}
+
+// renameJSONFields gets copy of fields with renamed struct types
+func renameJSONFields(fields []model.JSONField, renames map[string]string) []model.JSONField {
+	result := make([]model.JSONField, len(fields))
+	for i, field := range fields {
+		field.Type = renameJSONType(field.Type, renames)
+		result[i] = field
+	}
+
+	return result
+}
+
+// renameJSONType renames structs in type, e.g. []*UserMeta
+func renameJSONType(typ string, renames map[string]string) string {
+	return identifierRe.ReplaceAllStringFunc(typ, func(name string) string {
+		if renamed, ok := renames[name]; ok {
+			return renamed
+		}
+		return name
+	})
+}
+
+func resolveJSONType(entity model.Entity, column model.Column, options Options, sample jsonSampler) (*model.JSONType, error) {
+	name := entity.GoName + column.GoName
+
+	if path, ok := columnOverride(options.JSONSchemas, entity.PGSchema, entity.PGName, column.PGName); ok {
+		raw, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("reading json schema error: %w", err)
+		}
+		return model.NewJSONTypeFromSchema(name, raw)
+	}
+
+	if comment := strings.TrimSpace(column.Description); strings.HasPrefix(comment, "{") && json.Valid([]byte(comment)) {
+		return model.NewJSONTypeFromSchema(name, []byte(comment))
+	}
+
+	if options.JSONSample > 0 && sample != nil {
+		samples, err := sample(entity.PGSchema, entity.PGName, column.PGName, options.JSONSample)
+		if err != nil {
+			return nil, err
+		}
+		if len(samples) > 0 {
+			return model.NewJSONTypeFromSamples(name, samples)
+		}
+	}
+
+	return nil, nil
+}
+
+// packageJSONStructs collects go structs generated for json columns, structs with the same name are equal after ResolveJSONTypes
+func packageJSONStructs(entities []model.Entity) ([]model.JSONStruct, []string) {
+	var structs []model.JSONStruct
+	index := util.NewSet()
+	imports := util.NewSet()
+
+	for _, entity := range entities {
+		for _, column := range entity.Columns {
+			if column.JSONType == nil {
+				continue
+			}
+
+			for _, s := range column.JSONType.Structs {
+				if index.Add(s.GoName) {
+					structs = append(structs, s)
+				}
+			}
+			for _, imp := range column.JSONType.Imports {
+				imports.Add(imp)
+			}
+		}
+	}
+
+	return structs, imports.Elements()
+}
@@ -0,0 +1,109 @@
+package model
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/ant31/bungen/model"
+	"github.com/ant31/bungen/util"
+)
+
+func TestResolveJSONTypes(t *testing.T) {
+	schemaFile := filepath.Join(t.TempDir(), "settings.json")
+	if err := os.WriteFile(schemaFile, []byte(`{"type": "object", "properties": {"theme": {"type": "string"}}}`), 0644); err != nil {
+		t.Fatalf("write schema file error = %v", err)
+	}
+
+	settings := model.NewColumn("settings", model.TypePGJSONB, false, false, false, 0, false, false, 0, nil, nil)
+	profile := model.NewColumn("profile", model.TypePGJSONB, true, false, false, 0, false, false, 0, nil, nil)
+	profile.Description = `{"type": "object", "properties": {"bio": {"type": "string"}}}`
+	tags := model.NewColumn("tags", model.TypePGJSON, true, false, false, 0, false, false, 0, nil, nil)
+	raw := model.NewColumn("raw", model.TypePGJSON, true, false, false, 0, false, false, 0, nil, nil)
+	entity := model.NewEntity(util.PublicSchema, "users", []model.Column{settings, profile, tags, raw}, nil)
+
+	options := Options{
+		JSONStructs: true,
+		JSONSchemas: map[string]string{"users.settings": schemaFile},
+		JSONSample:  10,
+		JSONTypes:   map[string]string{"*": model.TypeMapInterface, "users.raw": "json.RawMessage"},
+	}
+
+	sampler := func(schema, table, column string, limit int) ([][]byte, error) {
+		return [][]byte{[]byte(`["a", "b"]`)}, nil
+	}
+
+	entities := []model.Entity{entity}
+	if err := ResolveJSONTypes(entities, options, sampler); err != nil {
+		t.Fatalf("ResolveJSONTypes() error = %v", err)
+	}
+
+	want := []string{"UserSettings", "UserProfile", "[]string", ""}
+	for i, column := range entities[0].Columns {
+		got := ""
+		if column.JSONType != nil {
+			got = column.JSONType.Type
+		}
+		if got != want[i] {
+			t.Errorf("ResolveJSONTypes() %s type = %v, want %v", column.PGName, got, want[i])
+		}
+	}
+
+	if got := NewTemplateColumn(entities[0], entities[0].Columns[1], options).Type; got != "*UserProfile" {
+		t.Errorf("NewTemplateColumn().Type = %v, want %v", got, "*UserProfile")
+	}
+
+	structs, _ := packageJSONStructs(entities)
+	if len(structs) != 2 {
+		t.Errorf("packageJSONStructs() = %v, want %v structs", len(structs), 2)
+	}
+}
+
+func TestResolveJSONTypes_conflicts(t *testing.T) {
+	jsonb := func(name string) model.Column {
+		return model.NewColumn(name, model.TypePGJSONB, false, false, false, 0, false, false, 0, nil, nil)
+	}
+	entities := []model.Entity{
+		model.NewEntity(util.PublicSchema, "users", []model.Column{jsonb("meta_data"), jsonb("meta"), jsonb("settings")}, nil),
+		model.NewEntity(util.PublicSchema, "user_metas", []model.Column{jsonb("data")}, nil),
+		model.NewEntity(util.PublicSchema, "projects", []model.Column{jsonb("settings")}, nil),
+	}
+	samples := map[string]string{
+		"users.meta_data":   `{"a": 1, "nested": {"x": 1}}`,
+		"users.meta":        `{"b": true}`,
+		"users.settings":    `{"theme": "dark"}`,
+		"user_metas.data":   `{"c": "x", "nested": {"y": "z"}}`,
+		"projects.settings": `{"theme": "light"}`,
+	}
+	sampler := func(schema, table, column string, limit int) ([][]byte, error) {
+		return [][]byte{[]byte(samples[table+"."+column])}, nil
+	}
+
+	if err := ResolveJSONTypes(entities, Options{JSONStructs: true, JSONSample: 1}, sampler); err != nil {
+		t.Fatalf("ResolveJSONTypes() error = %v", err)
+	}
+
+	var got []string
+	for _, entity := range entities {
+		for _, column := range entity.Columns {
+			got = append(got, column.JSONType.Type)
+		}
+	}
+	// struct named as entity and different struct with the same name are renamed
+	want := []string{"UserMetaData", "UserMeta1", "UserSettings", "UserMetaData1", "ProjectSettings"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ResolveJSONTypes() types = %v, want %v", got, want)
+	}
+
+	// nested struct is renamed in fields of renamed struct
+	renamed := entities[1].Columns[0].JSONType
+	if names := []string{renamed.Structs[0].GoName, renamed.Structs[1].GoName, renamed.Structs[1].Fields[1].Type}; !reflect.DeepEqual(names, []string{"UserMetaDataNested1", "UserMetaData1", "UserMetaDataNested1"}) {
+		t.Errorf("ResolveJSONTypes() renamed structs = %v", names)
+	}
+
+	structs, _ := packageJSONStructs(entities)
+	if len(structs) != 7 {
+		t.Errorf("packageJSONStructs() = %v, want %v structs", len(structs), 7)
+	}
+}
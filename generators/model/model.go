@@ -5,50 +5,192 @@ import (
 	"html/template"
 	"strings"
 
+	"github.com/ant31/bungen/generators/base"
 	"github.com/ant31/bungen/model"
 	"github.com/ant31/bungen/util"
 )
 
+const (
+	bunImport       = "github.com/uptrace/bun"
+	pgdialectImport = "github.com/uptrace/bun/dialect/pgdialect"
+)
+
 // TemplatePackage stores package info
 type TemplatePackage struct {
 	Package string
 
 	HasImports bool
 	Imports    []string
+	// SearchImports are imported by search structs generated apart from models
+	SearchImports []string
 
 	Entities []TemplateEntity
 
 	WithORM        bool
 	WithSearch     bool
 	WithValidation bool
+	WithCopy       bool
 	ORMDbStruct    string
+
+	Composites       []model.Composite
+	CompositeImports []string
+
+	JSONStructs []model.JSONStruct
+	JSONImports []string
+
+	Embeds       []TemplateEmbed
+	EmbedImports []string
+
+	// HasAudit is set if hooks fill columns with user from context by AuditKey
+	HasAudit       bool
+	AuditKey       string
+	AuditKeyImport string
+
+	// HasConstraints is set if any entity has sentinel errors of constraint violations
+	HasConstraints bool
+
+	HasNetIP bool
 }
 
 // NewTemplatePackage creates a package for template
 func NewTemplatePackage(entities []model.Entity, options Options) TemplatePackage {
 	imports := util.NewSet()
+	searchImports := util.NewSet()
 
 	models := make([]TemplateEntity, len(entities))
 	for i, entity := range entities {
-		for _, imp := range entity.Imports {
+		models[i] = NewTemplateEntity(entity, options)
+
+		for _, imp := range models[i].Imports {
 			imports.Add(imp)
 		}
-
-		models[i] = NewTemplateEntity(entity, options)
+		for _, imp := range models[i].SearchImports {
+			searchImports.Add(imp)
+		}
 	}
 
+	composites, compositeImports := packageComposites(entities)
+	jsonStructs, jsonImports := packageJSONStructs(entities)
+	embeds, embedImports := packageEmbeds(models)
+	auditKey, auditKeyImport, _ := model.ParseGoType(options.AuditKey)
+
 	return TemplatePackage{
 		Package: options.Package,
 
-		HasImports: imports.Len() > 0,
-		Imports:    imports.Elements(),
+		HasImports:    imports.Len() > 0,
+		Imports:       imports.Elements(),
+		SearchImports: searchImports.Elements(),
 
 		Entities:       models,
 		WithORM:        options.WithORM,
 		ORMDbStruct:    options.DBWrapName,
 		WithValidation: options.WithValidation,
 		WithSearch:     options.WithSearch,
+		WithCopy:       options.WithCopy,
+
+		Composites:       composites,
+		CompositeImports: compositeImports,
+
+		JSONStructs: jsonStructs,
+		JSONImports: jsonImports,
+
+		Embeds:       embeds,
+		EmbedImports: embedImports,
+
+		HasAudit:       hasAudit(models),
+		AuditKey:       auditKey,
+		AuditKeyImport: auditKeyImport,
+
+		HasConstraints: hasConstraints(models),
+
+		HasNetIP: options.UseNetIP && usesNetIP(entities),
+	}
+}
+
+// hasAudit checks if any hook fills columns with user from context
+func hasAudit(models []TemplateEntity) bool {
+	for _, entity := range models {
+		if entity.Hook != nil && entity.Hook.HasUsers() {
+			return true
+		}
+	}
+
+	return false
+}
+
+// hasConstraints checks if any entity has sentinel errors of constraint violations
+func hasConstraints(models []TemplateEntity) bool {
+	for _, entity := range models {
+		if len(entity.Constraints) > 0 {
+			return true
+		}
+	}
+
+	return false
+}
+
+// isNetIP checks if column uses generated network type
+func isNetIP(column model.Column) bool {
+	typ, ok := base.NetIPTypes[column.PGType]
+	return ok && column.GoType == typ
+}
+
+// usesNetIP checks if any column or composite field uses generated network types
+func usesNetIP(entities []model.Entity) bool {
+	var uses func(columns []model.Column) bool
+	uses = func(columns []model.Column) bool {
+		for _, column := range columns {
+			if isNetIP(column) {
+				return true
+			}
+			if column.Composite != nil && uses(column.Composite.Fields) {
+				return true
+			}
+		}
+		return false
+	}
+
+	for _, entity := range entities {
+		if uses(entity.Columns) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// packageComposites collects composite types used by entities columns, including nested ones
+func packageComposites(entities []model.Entity) ([]model.Composite, []string) {
+	// imported by types template
+	imports := util.NewSet()
+	for _, imp := range []string{"database/sql", "database/sql/driver", "encoding/hex", "fmt", "reflect", "strconv", "strings", "time"} {
+		imports.Add(imp)
+	}
+	predefined := imports.Len()
+
+	var composites []model.Composite
+	index := util.NewSet()
+
+	var collect func(columns []model.Column)
+	collect = func(columns []model.Column) {
+		for _, column := range columns {
+			if column.Composite == nil || !index.Add(column.Composite.PGFullName) {
+				continue
+			}
+
+			collect(column.Composite.Fields)
+			composites = append(composites, *column.Composite)
+			for _, imp := range column.Composite.Imports {
+				imports.Add(imp)
+			}
+		}
 	}
+
+	for _, entity := range entities {
+		collect(entity.Columns)
+	}
+
+	return composites, imports.Elements()[predefined:]
 }
 
 // TemplateEntity stores struct info
@@ -61,10 +203,49 @@ type TemplateEntity struct {
 	Alias   string
 
 	Columns []TemplateColumn
+	PKs     []TemplateColumn
+	// Filters are operator fields of search struct
+	Filters []TemplateFilter
+	// SortColumns can be used in search sort, FieldColumns in search projection
+	// KeyColumns are always selected with search projection
+	SortColumns  []TemplateColumn
+	FieldColumns []TemplateColumn
+	KeyColumns   []TemplateColumn
+	// SortField and FieldsField are names of search struct fields for sort and projection
+	SortField   string
+	FieldsField string
+	// Params are query parameters parsed into search struct
+	Params []TemplateParam
 
 	HasRelations bool
 	Relations    []TemplateRelation
 	Imports      []string
+	// Embeds are structs with shared columns embedded into model
+	Embeds []string
+	// SearchImports are imports of search structs generated apart from models
+	SearchImports []string
+
+	Finders []TemplateFinder
+	Upserts []TemplateUpsert
+	// UpsertColumns are updated on conflict by default
+	UpsertColumns []TemplateColumn
+	Pages         []TemplatePage
+	// Checks are validation rules of Validate method
+	Checks []TemplateCheck
+	// Unchecked are CHECK constraints which are not translated into validation rules
+	Unchecked []TemplateUnchecked
+	// Constraints are sentinel errors of constraint violations
+	Constraints []TemplateConstraint
+	// Hook fills timestamp and audit columns on insert and update, nil if model has no such columns
+	Hook *TemplateHook
+	// SoftDelete is a column marking deleted rows, nil if rows are deleted from table
+	SoftDelete *TemplateSoftDelete
+	// Version is a column checked and incremented by update and delete queries, nil if there is no such column
+	Version *TemplateColumn
+	// PatchColumns are compared by Diff and set by patch
+	PatchColumns []TemplatePatchColumn
+	// CopyColumns are written by COPY loader
+	CopyColumns []TemplateCopyColumn
 }
 
 // NewTemplateEntity creates an entity for template
@@ -74,11 +255,31 @@ func NewTemplateEntity(entity model.Entity, options Options) TemplateEntity {
 	}
 	imports := util.NewSet()
 	columns := make([]TemplateColumn, len(entity.Columns))
+	var pks []TemplateColumn
 	for i, column := range entity.Columns {
 		columns[i] = NewTemplateColumn(entity, column, options)
-		if columns[i].Import != "" {
-			imports.Add(column.Import)
+		if column.IsPK {
+			pks = append(pks, columns[i])
+		}
+		// bun is always imported by templates, embedded structs import their own types
+		if imp := columns[i].Import; imp != "" && imp != bunImport && columns[i].GoType != model.TypeInterface && column.Embed == "" {
+			imports.Add(imp)
+		}
+	}
+
+	filters := newTemplateFilters(entity, columns, options)
+	searchImports := newSearchImports(columns, filters, options)
+	if options.WithSearch {
+		if usesArrayFilters(filters) {
+			imports.Add(pgdialectImport)
+		}
+		if options.Relaxed {
+			imports.Add("reflect")
 		}
+		// sort, fields and query parameters parsers
+		imports.Add("fmt")
+		imports.Add("net/url")
+		imports.Add("strings")
 	}
 
 	relations := make([]TemplateRelation, 0, len(entity.Relations))
@@ -107,7 +308,43 @@ func NewTemplateEntity(entity model.Entity, options Options) TemplateEntity {
 		// tags.AddTag("bun", "discard_unknown_columns")
 	}
 
-	return TemplateEntity{
+	pages := newTemplatePages(entity, columns)
+	finders := newTemplateFinders(entity, columns)
+	constraintChecks, unchecked := newTemplateConstraintChecks(entity, columns)
+	hook := newTemplateHook(columns, options)
+	patchColumns := newTemplatePatchColumns(columns, hook, options)
+	for _, imp := range embeddedImports(columns, pks, finders, pages, patchColumns, options) {
+		imports.Add(imp)
+	}
+
+	if hook != nil {
+		// context is imported by model template with ORM or COPY
+		if !options.WithORM && !options.WithCopy {
+			imports.Add("context")
+		}
+		if hook.HasTimes() {
+			imports.Add("time")
+		}
+		for _, column := range append(hook.CreatedBy, hook.UpdatedBy...) {
+			if imp := column.Import; imp != "" && imp != bunImport {
+				imports.Add(imp)
+			}
+		}
+	}
+
+	version := newTemplateVersion(columns)
+	softDelete := newTemplateSoftDelete(columns, filters, hook, version)
+	// deletion time of unix and time kinds is set by queries
+	if softDelete != nil && softDelete.Update && softDelete.Kind != SoftDeleteBool && options.WithORM {
+		imports.Add("time")
+	}
+
+	// COPY export writes to io.Writer
+	if options.WithCopy {
+		imports.Add("io")
+	}
+
+	templateEntity := TemplateEntity{
 		Entity: entity,
 		Tag:    template.HTML(fmt.Sprintf("`%s`", tags.String())),
 
@@ -115,10 +352,47 @@ func NewTemplateEntity(entity model.Entity, options Options) TemplateEntity {
 		Alias:   util.DefaultAlias,
 
 		Columns: columns,
+		PKs:     pks,
+		Filters: filters,
+
+		SortColumns:  sortColumns(columns),
+		FieldColumns: fieldColumns(columns),
+		KeyColumns:   keyColumns(pks, pages),
+		SortField:    searchFieldName("Sort", columns, filters),
+		FieldsField:  searchFieldName("Fields", columns, filters),
 
 		HasRelations: len(relations) > 0,
 		Relations:    relations,
+		Imports:      imports.Elements(),
+		Embeds:       entityEmbeds(columns),
+
+		SearchImports: searchImports,
+
+		Finders:       finders,
+		Upserts:       newTemplateUpserts(entity, columns),
+		UpsertColumns: upsertColumns(columns, hook),
+		Pages:         pages,
+		Checks:        append(newTemplateChecks(columns), constraintChecks...),
+		Unchecked:     unchecked,
+		Constraints:   newTemplateConstraints(entity, columns),
+		Hook:          hook,
+		SoftDelete:    softDelete,
+		Version:       version,
+		PatchColumns:  patchColumns,
+		CopyColumns:   newTemplateCopyColumns(columns),
+	}
+	templateEntity.Params = newTemplateParams(templateEntity, options)
+
+	return templateEntity
+}
+
+// sqlType gets type used in tag, arrays get brackets
+func sqlType(pgType string, array bool) string {
+	if array {
+		return pgType + "[]"
 	}
+
+	return pgType
 }
 
 // TemplateColumn stores column info
@@ -132,6 +406,15 @@ type TemplateColumn struct {
 	HasTags         bool
 	UseCustomRender bool
 	CustomRender    template.HTML
+
+	// ParamName and ParamType are used when column value is an argument of generated function
+	ParamName string
+	ParamType string
+
+	// SoftDelete is a kind of soft delete column, empty for other columns
+	SoftDelete string
+	// Version is set for optimistic locking column
+	Version bool
 }
 
 // NewTemplateColumn creates a column for template
@@ -140,10 +423,31 @@ func NewTemplateColumn(entity model.Entity, column model.Column, options Options
 		column.GoName = util.ID
 	}
 
+	if name, ok := columnOverride(options.FieldNames, entity.PGSchema, entity.PGName, column.PGName); ok {
+		column.GoName = name
+	}
+
 	if column.PGType == model.TypePGJSON || column.PGType == model.TypePGJSONB {
-		if typ, ok := jsonType(options.JSONTypes, entity.PGSchema, entity.PGName, column.PGName); ok {
+		if typ, ok := columnOverride(options.JSONTypes, entity.PGSchema, entity.PGName, column.PGName); ok {
 			column.Type = typ
 		}
+
+		// generated structs
+		if column.JSONType != nil {
+			column.GoType = column.JSONType.Type
+			column.Type = column.JSONType.Type
+			if column.Nullable && column.JSONType.IsStruct() {
+				column.Type = "*" + column.Type
+			}
+		}
+	}
+
+	if raw, ok := columnOverride(options.TypeOverrides, entity.PGSchema, entity.PGName, column.PGName); ok {
+		if typ, imp, err := model.ParseGoType(raw); err == nil {
+			column.Type = typ
+			column.GoType = strings.TrimPrefix(typ, "*")
+			column.Import = imp
+		}
 	}
 
 	comment := ""
@@ -160,6 +464,10 @@ func NewTemplateColumn(entity model.Entity, column model.Column, options Options
 	// pk tag
 	if column.IsPK {
 		tags.AddTag(tagName, "pk")
+		// zero value is replaced with DEFAULT on insert
+		if column.IsSerial() {
+			tags.AddTag(tagName, "autoincrement")
+		}
 	}
 
 	// types tag
@@ -176,14 +484,37 @@ func NewTemplateColumn(entity model.Entity, column model.Column, options Options
 		tags.AddTag(tagName, "type:uuid")
 
 	}
-	// nullable tag
-	if !column.Nullable && !column.IsPK {
+	// bun casts values of unknown types to JSONB in bulk queries
+	if isNetIP(column) {
+		tags.AddTag(tagName, "type:"+sqlType(column.PGType, column.IsArray))
+	}
+	if column.Composite != nil {
+		tags.AddTag(tagName, "type:"+sqlType(util.Join(column.Composite.PGSchema, column.Composite.PGName), column.IsArray))
+	}
+	// generated columns are computed by database
+	if column.IsGenerated {
+		tags.AddTag(tagName, "scanonly")
+	}
+
+	softDelete := ""
+	if softDeleteColumn(entity, options) == column.PGName {
+		softDelete = softDeleteKind(column)
+	}
+
+	version := versionColumn(entity, options) == column.PGName && isVersionable(column)
+
+	// nullable tag, zero values of bool and unix soft delete columns mark alive rows, version starts with zero
+	if !column.Nullable && !column.IsPK && softDelete == "" && !version {
 		tags.AddTag(tagName, "nullzero")
 	}
 
-	// soft_delete tag
-	if options.SoftDelete == column.PGName && column.Nullable && column.GoType == model.TypeTime && !column.IsArray {
-		tags.AddTag("bun", ",soft_delete")
+	// soft_delete tag, other kinds of soft delete columns are handled by generated queries
+	if softDelete == SoftDeleteTime {
+		// bun compares non pointer fields with zero time instead of NULL
+		if !strings.HasPrefix(column.Type, "*") {
+			tags.AddTag(tagName, "nullzero")
+		}
+		tags.AddTag(tagName, "soft_delete")
 	}
 
 	// ignore tag
@@ -197,15 +528,38 @@ func NewTemplateColumn(entity model.Entity, column model.Column, options Options
 		tags.AddTag("json", util.Underscore(column.PGName))
 	}
 
+	// additional tags
+	if raw, ok := columnOverride(options.FieldTags, entity.PGSchema, entity.PGName, column.PGName); ok {
+		if extra, err := util.ParseAnnotation(raw); err == nil {
+			tags.Merge(extra)
+		}
+	}
+
+	paramType := column.GoType
+	if column.IsArray {
+		paramType = column.Type
+	}
+
 	return TemplateColumn{
 		Relaxed: options.Relaxed,
 		Column:  column,
 		HasTags: tags.Len() > 0,
 		Tag:     template.HTML(fmt.Sprintf("`%s`", tags.String())),
 		Comment: template.HTML(comment),
+
+		ParamName: model.Safe(util.LowerCamel(column.GoName), paramReserved),
+		ParamType: paramType,
+
+		SoftDelete: softDelete,
+		Version:    version,
 	}
 }
 
+// IsComparable checks if column can be used in equality lookups
+func (c TemplateColumn) IsComparable() bool {
+	return !c.IsArray && c.GoType != model.TypeInterface && c.PGType != model.TypePGJSON && c.PGType != model.TypePGJSONB
+}
+
 // TemplateRelation stores relation info
 type TemplateRelation struct {
 	model.Relation
@@ -265,34 +619,6 @@ func NewTemplateRelationWithJoin(relation model.Relation, relFK, relPK string, o
 	}
 }
 
-func jsonType(mp map[string]string, schema, table, field string) (string, bool) {
-	if mp == nil {
-		return "", false
-	}
-
-	patterns := [][3]string{
-		{schema, table, field},
-		{schema, "*", field},
-		{schema, table, "*"},
-		{schema, "*", "*"},
-	}
-
-	var names []string
-	for _, parts := range patterns {
-		names = append(names, fmt.Sprintf("%s.%s", util.Join(parts[0], parts[1]), parts[2]))
-		names = append(names, fmt.Sprintf("%s.%s", util.JoinF(parts[0], parts[1]), parts[2]))
-	}
-	names = append(names, util.Join(schema, table), "*")
-
-	for _, name := range names {
-		if v, ok := mp[name]; ok {
-			return v, true
-		}
-	}
-
-	return "", false
-}
-
 func tagName(options Options) string {
 	return "bun"
 }
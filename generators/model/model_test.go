@@ -0,0 +1,134 @@
+package model
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/ant31/bungen/generators/base"
+	"github.com/ant31/bungen/model"
+	"github.com/ant31/bungen/util"
+)
+
+func Test_packageComposites(t *testing.T) {
+	ct := model.CustomTypeMapping{}
+
+	point := model.NewComposite(util.PublicSchema, "point2")
+	point.AddField(model.NewColumn("x", model.TypePGInt4, true, false, false, 0, false, false, 0, nil, ct))
+	ct.Add("point2", point.GoName, "")
+
+	location := model.NewComposite("geo", "location")
+	location.AddField(model.NewColumn("since", model.TypePGTimestamptz, true, false, false, 0, false, false, 0, nil, ct))
+	location.AddField(model.NewColumn("label", model.TypePGUuid, true, false, false, 0, false, false, 0, nil, model.CustomTypeMapping{
+		model.TypePGUuid: {PGType: model.TypePGUuid, GoType: "uuid.UUID", GoImport: "github.com/google/uuid"},
+	}))
+	pos := model.NewColumn("pos", "point2", true, false, false, 0, false, false, 0, nil, ct)
+	pos.Composite = &point
+	location.AddField(pos)
+	ct.Add("location", location.GoName, "")
+
+	capital := model.NewColumn("capital", "location", true, false, false, 0, false, false, 0, nil, ct)
+	capital.Composite = &location
+	home := model.NewColumn("home", "location", true, false, false, 0, false, false, 0, nil, ct)
+	home.Composite = &location
+
+	entity := model.NewEntity("geo", "countries", []model.Column{capital, home}, nil)
+
+	composites, imports := packageComposites([]model.Entity{entity})
+
+	names := make([]string, len(composites))
+	for i, composite := range composites {
+		names[i] = composite.GoName
+	}
+	if want := []string{"Point2", "GeoLocation"}; !reflect.DeepEqual(names, want) {
+		t.Errorf("packageComposites() composites = %v, want %v", names, want)
+	}
+	if want := []string{"github.com/google/uuid"}; !reflect.DeepEqual(imports, want) {
+		t.Errorf("packageComposites() imports = %v, want %v", imports, want)
+	}
+}
+
+func Test_usesNetIP(t *testing.T) {
+	ct := model.CustomTypeMapping{}
+	for pgType, goType := range base.NetIPTypes {
+		ct.Add(pgType, goType, "")
+	}
+
+	tests := []struct {
+		name    string
+		columns []model.Column
+		want    bool
+	}{
+		{
+			name:    "Should detect inet column",
+			columns: []model.Column{model.NewColumn("ip", model.TypePGInet, true, false, false, 0, false, false, 0, nil, ct)},
+			want:    true,
+		},
+		{
+			name:    "Should detect macaddr array column",
+			columns: []model.Column{model.NewColumn("macs", model.TypePGMacaddr, true, false, true, 1, false, false, 0, nil, ct)},
+			want:    true,
+		},
+		{
+			name:    "Should skip overridden custom type",
+			columns: []model.Column{model.NewColumn("ip", model.TypePGInet, true, false, false, 0, false, false, 0, nil, nil)},
+			want:    false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			entity := model.NewEntity(util.PublicSchema, "hosts", tt.columns, nil)
+			if got := usesNetIP([]model.Entity{entity}); got != tt.want {
+				t.Errorf("usesNetIP() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewTemplateEntity_PKs(t *testing.T) {
+	id := model.NewColumn("userId", model.TypePGInt8, false, false, false, 0, true, false, 0, nil, nil)
+	id.Default = "nextval('users_id_seq'::regclass)"
+	email := model.NewColumn("email", model.TypePGText, false, false, false, 0, false, false, 0, nil, nil)
+
+	entity := NewTemplateEntity(model.NewEntity(util.PublicSchema, "users", []model.Column{id, email}, nil), Options{})
+
+	if len(entity.PKs) != 1 || entity.PKs[0].GoName != util.ID {
+		t.Fatalf("NewTemplateEntity().PKs = %v, want [%s]", entity.PKs, util.ID)
+	}
+	if got, want := string(entity.PKs[0].Tag), "`bun:\"userId,pk,autoincrement\"`"; got != want {
+		t.Errorf("NewTemplateEntity().PKs[0].Tag = %v, want %v", got, want)
+	}
+}
+
+func TestNewTemplateColumn_TypeTags(t *testing.T) {
+	ct := model.CustomTypeMapping{}
+	for pgType, goType := range base.NetIPTypes {
+		ct.Add(pgType, goType, "")
+	}
+	location := model.NewComposite("geo", "location")
+	ct.Add("location", location.GoName, "")
+
+	capital := model.NewColumn("capital", "location", true, false, false, 0, false, false, 0, nil, ct)
+	capital.Composite = &location
+	stops := model.NewColumn("stops", "location", true, false, true, 1, false, false, 0, nil, ct)
+	stops.Composite = &location
+
+	tests := []struct {
+		name   string
+		column model.Column
+		want   string
+	}{
+		{name: "Should tag composite", column: capital, want: "`bun:\"capital,type:geo.location\"`"},
+		{name: "Should tag array of composites", column: stops, want: "`bun:\"stops,array,type:geo.location[]\"`"},
+		{name: "Should tag inet", column: model.NewColumn("ip", model.TypePGInet, true, false, false, 0, false, false, 0, nil, ct), want: "`bun:\"ip,type:inet\"`"},
+		{name: "Should tag array of cidr", column: model.NewColumn("nets", model.TypePGCidr, true, false, true, 1, false, false, 0, nil, ct), want: "`bun:\"nets,array,type:cidr[]\"`"},
+		{name: "Should not tag net.IP", column: model.NewColumn("ip", model.TypePGInet, true, false, false, 0, false, false, 0, nil, nil), want: "`bun:\"ip\"`"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			entity := model.NewEntity("geo", "countries", []model.Column{tt.column}, nil)
+			if got := NewTemplateColumn(entity, tt.column, Options{}).Tag; string(got) != tt.want {
+				t.Errorf("NewTemplateColumn() tag = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,116 @@
+package model
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/ant31/bungen/model"
+	"github.com/ant31/bungen/util"
+)
+
+// columnPatterns returns all override keys matching column, most specific first
+func columnPatterns(schema, table, field string) []string {
+	patterns := [][3]string{
+		{schema, table, field},
+		{schema, "*", field},
+		{schema, table, "*"},
+		{schema, "*", "*"},
+	}
+
+	var names []string
+	for _, parts := range patterns {
+		names = append(names, fmt.Sprintf("%s.%s", util.Join(parts[0], parts[1]), parts[2]))
+		names = append(names, fmt.Sprintf("%s.%s", util.JoinF(parts[0], parts[1]), parts[2]))
+	}
+
+	return append(names, util.Join(schema, table), "*")
+}
+
+// columnOverride finds value of the most specific pattern matching column
+func columnOverride(mp map[string]string, schema, table, field string) (string, bool) {
+	if mp == nil {
+		return "", false
+	}
+
+	for _, name := range columnPatterns(schema, table, field) {
+		if v, ok := mp[name]; ok {
+			return v, true
+		}
+	}
+
+	return "", false
+}
+
+// UnmatchedOverrides returns warnings for override patterns which do not match any column
+func UnmatchedOverrides(entities []model.Entity, options Options) []string {
+	used := map[string]struct{}{}
+	for _, entity := range entities {
+		for _, column := range entity.Columns {
+			for _, name := range columnPatterns(entity.PGSchema, entity.PGName, column.PGName) {
+				used[name] = struct{}{}
+			}
+		}
+	}
+
+	var warnings []string
+	check := func(flag string, mp map[string]string) {
+		patterns := make([]string, 0, len(mp))
+		for pattern := range mp {
+			patterns = append(patterns, pattern)
+		}
+		sort.Strings(patterns)
+
+		for _, pattern := range patterns {
+			if _, ok := used[pattern]; !ok {
+				warnings = append(warnings, fmt.Sprintf("%s: pattern %q does not match any column", flag, pattern))
+			}
+		}
+	}
+
+	check("type-override", options.TypeOverrides)
+	check("field-name", options.FieldNames)
+	check("field-tag", options.FieldTags)
+	check("json-schema", options.JSONSchemas)
+	check("search-params", options.SearchParams)
+	check("json-type", options.JSONTypes)
+
+	return warnings
+}
+
+// FieldNameConflicts returns errors for field-name overrides giving a field a name already used in its entity
+func FieldNameConflicts(entities []model.Entity, options Options) []string {
+	var conflicts []string
+	for _, entity := range entities {
+		used := map[string]string{}
+		for _, name := range model.ReservedFieldNames {
+			used[name] = "generated field"
+		}
+		for _, relation := range entity.Relations {
+			used[relation.GoName] = "relation"
+		}
+
+		var renamed []model.Column
+		for _, column := range entity.Columns {
+			if name, ok := columnOverride(options.FieldNames, entity.PGSchema, entity.PGName, column.PGName); ok {
+				column.GoName = name
+				renamed = append(renamed, column)
+				continue
+			}
+			if !options.KeepPK && column.IsPK {
+				column.GoName = util.ID
+			}
+			used[column.GoName] = fmt.Sprintf("column %s", column.PGName)
+		}
+
+		for _, column := range renamed {
+			if owner, ok := used[column.GoName]; ok {
+				conflicts = append(conflicts, fmt.Sprintf("field-name: %s.%s is renamed to %s already used by %s",
+					util.Join(entity.PGSchema, entity.PGName), column.PGName, column.GoName, owner))
+				continue
+			}
+			used[column.GoName] = fmt.Sprintf("column %s", column.PGName)
+		}
+	}
+
+	return conflicts
+}
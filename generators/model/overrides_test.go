@@ -0,0 +1,158 @@
+package model
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/ant31/bungen/model"
+	"github.com/ant31/bungen/util"
+)
+
+func Test_columnOverride(t *testing.T) {
+	tests := []struct {
+		name   string
+		mp     map[string]string
+		schema string
+		table  string
+		field  string
+		want   string
+		wantOk bool
+	}{
+		{
+			name:   "Should find exact column",
+			mp:     map[string]string{"billing.invoices.amount": "money.Amount", "billing.*.amount": "float64"},
+			schema: "billing",
+			table:  "invoices",
+			field:  "amount",
+			want:   "money.Amount",
+			wantOk: true,
+		},
+		{
+			name:   "Should find column in any table",
+			mp:     map[string]string{"billing.*.amount": "float64"},
+			schema: "billing",
+			table:  "payments",
+			field:  "amount",
+			want:   "float64",
+			wantOk: true,
+		},
+		{
+			name:   "Should find public column without schema",
+			mp:     map[string]string{"users.email": "Email"},
+			schema: util.PublicSchema,
+			table:  "users",
+			field:  "email",
+			want:   "Email",
+			wantOk: true,
+		},
+		{
+			name:   "Should not find other column",
+			mp:     map[string]string{"users.email": "Email"},
+			schema: util.PublicSchema,
+			table:  "users",
+			field:  "name",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := columnOverride(tt.mp, tt.schema, tt.table, tt.field)
+			if got != tt.want || ok != tt.wantOk {
+				t.Errorf("columnOverride() = %v, %v, want %v, %v", got, ok, tt.want, tt.wantOk)
+			}
+		})
+	}
+}
+
+func TestNewTemplateColumn_Overrides(t *testing.T) {
+	entity := model.NewEntity("billing", "invoices", nil, nil)
+	column := model.NewColumn("amount", model.TypePGNumeric, true, false, false, 0, false, false, 0, nil, nil)
+
+	options := Options{
+		TypeOverrides: map[string]string{"billing.invoices.amount": "github.com/acme/money.Amount"},
+		FieldNames:    map[string]string{"billing.invoices.amount": "Total"},
+		FieldTags:     map[string]string{"billing.*.amount": "validate:gte=0"},
+	}
+
+	got := NewTemplateColumn(entity, column, options)
+	if got.GoName != "Total" {
+		t.Errorf("NewTemplateColumn().GoName = %v, want %v", got.GoName, "Total")
+	}
+	if got.Type != "money.Amount" {
+		t.Errorf("NewTemplateColumn().Type = %v, want %v", got.Type, "money.Amount")
+	}
+	if got.Import != "github.com/acme/money" {
+		t.Errorf("NewTemplateColumn().Import = %v, want %v", got.Import, "github.com/acme/money")
+	}
+	if want := "`bun:\"amount\" validate:\"gte=0\"`"; string(got.Tag) != want {
+		t.Errorf("NewTemplateColumn().Tag = %v, want %v", got.Tag, want)
+	}
+}
+
+func TestUnmatchedOverrides(t *testing.T) {
+	entity := model.NewEntity(util.PublicSchema, "users", nil, nil)
+	entity.AddColumn(model.NewColumn("email", model.TypePGVarchar, false, false, false, 0, false, false, 0, nil, nil))
+
+	options := Options{
+		TypeOverrides: map[string]string{"users.email": "Email", "users.mail": "Email"},
+		FieldNames:    map[string]string{"public.*.email": "Mail"},
+		FieldTags:     map[string]string{"orders.*": "validate:required"},
+		JSONTypes:     map[string]string{"*": "map[string]any", "users.settings": "Settings"},
+	}
+
+	want := []string{
+		`type-override: pattern "users.mail" does not match any column`,
+		`field-tag: pattern "orders.*" does not match any column`,
+		`json-type: pattern "users.settings" does not match any column`,
+	}
+	if got := UnmatchedOverrides([]model.Entity{entity}, options); !reflect.DeepEqual(got, want) {
+		t.Errorf("UnmatchedOverrides() = %v, want %v", got, want)
+	}
+}
+
+func TestFieldNameConflicts(t *testing.T) {
+	entity := model.NewEntity(util.PublicSchema, "users", nil, nil)
+	entity.AddColumn(model.NewColumn("user_id", model.TypePGInt8, false, false, false, 0, true, false, 0, nil, nil))
+	entity.AddColumn(model.NewColumn("email", model.TypePGVarchar, false, false, false, 0, false, false, 0, nil, nil))
+	entity.AddColumn(model.NewColumn("mail", model.TypePGVarchar, false, false, false, 0, false, false, 0, nil, nil))
+	entity.AddColumn(model.NewColumn("login", model.TypePGVarchar, false, false, false, 0, false, false, 0, nil, nil))
+	entity.AddColumn(model.NewColumn("code", model.TypePGVarchar, false, false, false, 0, false, false, 0, nil, nil))
+
+	tests := []struct {
+		name       string
+		fieldNames map[string]string
+		want       []string
+	}{
+		{
+			name:       "Should accept unique names",
+			fieldNames: map[string]string{"users.email": "EmailAddress", "users.user_id": "UserID"},
+		},
+		{
+			name:       "Should report name of other column",
+			fieldNames: map[string]string{"users.email": "Mail"},
+			want:       []string{"field-name: public.users.email is renamed to Mail already used by column mail"},
+		},
+		{
+			name:       "Should report name of primary key",
+			fieldNames: map[string]string{"users.code": "ID"},
+			want:       []string{"field-name: public.users.code is renamed to ID already used by column user_id"},
+		},
+		{
+			name:       "Should report two renamed columns",
+			fieldNames: map[string]string{"users.email": "Contact", "users.login": "Contact"},
+			want:       []string{"field-name: public.users.login is renamed to Contact already used by column email"},
+		},
+		{
+			name:       "Should report generated field",
+			fieldNames: map[string]string{"users.code": "PK"},
+			want:       []string{"field-name: public.users.code is renamed to PK already used by generated field"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := FieldNameConflicts([]model.Entity{entity}, Options{FieldNames: tt.fieldNames})
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("FieldNameConflicts() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
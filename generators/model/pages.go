@@ -0,0 +1,68 @@
+package model
+
+import (
+	"strings"
+
+	"github.com/ant31/bungen/model"
+)
+
+// TemplatePage stores keyset paginator generated from primary key or unique index
+type TemplatePage struct {
+	// Name is a method name suffix, e.g. ByEmail, empty for primary key
+	Name  string
+	Index string
+	// Columns are keyset columns, rows are ordered by them
+	Columns []TemplateColumn
+}
+
+// newTemplatePages generates paginators for primary key and unique indexes
+// keyset columns must be not null and comparable, otherwise rows can't be ordered stable
+func newTemplatePages(entity model.Entity, columns []TemplateColumn) []TemplatePage {
+	byName := map[string]TemplateColumn{}
+	var pks []TemplateColumn
+	for _, column := range columns {
+		byName[column.PGName] = column
+		if column.IsPK {
+			pks = append(pks, column)
+		}
+	}
+
+	var pages []TemplatePage
+	if len(pks) > 0 && keysetColumns(pks) {
+		pages = append(pages, TemplatePage{Index: "primary key", Columns: pks})
+	}
+
+	for _, index := range entity.UniqueIndexes() {
+		if columnSet(index.Columns) == columnSet(pkNames(pks)) {
+			continue
+		}
+
+		cols := make([]TemplateColumn, 0, len(index.Columns))
+		goNames := make([]string, 0, len(index.Columns))
+		for _, name := range index.Columns {
+			if column, ok := byName[name]; ok {
+				cols = append(cols, column)
+				goNames = append(goNames, column.GoName)
+			}
+		}
+
+		if len(cols) == len(index.Columns) && keysetColumns(cols) {
+			pages = append(pages, TemplatePage{
+				Name:    "By" + strings.Join(goNames, "And"),
+				Index:   index.Name,
+				Columns: cols,
+			})
+		}
+	}
+
+	return pages
+}
+
+func keysetColumns(columns []TemplateColumn) bool {
+	for _, column := range columns {
+		if column.Nullable || !column.IsComparable() {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,34 @@
+package model
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/ant31/bungen/model"
+	"github.com/ant31/bungen/util"
+)
+
+func Test_newTemplatePages(t *testing.T) {
+	columns := []model.Column{
+		model.NewColumn("userId", model.TypePGInt8, false, false, false, 0, true, false, 0, nil, nil),
+		model.NewColumn("email", model.TypePGVarchar, false, false, false, 0, false, false, 0, nil, nil),
+		model.NewColumn("name", model.TypePGText, true, false, false, 0, false, false, 0, nil, nil),
+		model.NewColumn("tags", model.TypePGText, false, false, true, 1, false, false, 0, nil, nil),
+	}
+
+	entity := model.NewEntity(util.PublicSchema, "users", columns, nil)
+	entity.AddIndex(model.NewIndex("users_pkey", []string{"userId"}, true, true, "btree", false, false))
+	entity.AddIndex(model.NewIndex("users_email_key", []string{"email"}, true, false, "btree", false, false))
+	entity.AddIndex(model.NewIndex("users_name_key", []string{"name"}, true, false, "btree", false, false))
+	entity.AddIndex(model.NewIndex("users_tags_key", []string{"tags"}, true, false, "btree", false, false))
+
+	templateEntity := NewTemplateEntity(entity, Options{})
+
+	names := make([]string, len(templateEntity.Pages))
+	for i, page := range templateEntity.Pages {
+		names[i] = page.Name
+	}
+	if want := []string{"", "ByEmail"}; !reflect.DeepEqual(names, want) {
+		t.Errorf("NewTemplateEntity().Pages = %v, want %v", names, want)
+	}
+}
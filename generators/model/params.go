@@ -0,0 +1,78 @@
+package model
+
+import (
+	"fmt"
+	"html/template"
+	"strings"
+
+	"github.com/ant31/bungen/model"
+)
+
+// TemplateParam stores query parameter parsed into search field
+type TemplateParam struct {
+	// Name is a query parameter name, e.g. email or createdAt[gte]
+	Name string
+	// Target is a pointer to search field or a function setting it
+	Target template.HTML
+}
+
+// newTemplateParams generates query parameters for search fields allowed by options
+// sort and fields parameters are added if there are no columns with such names
+func newTemplateParams(entity TemplateEntity, options Options) []TemplateParam {
+	var params []TemplateParam
+	add := func(name, target string) {
+		params = append(params, TemplateParam{Name: name, Target: template.HTML(target)})
+	}
+
+	for _, column := range entity.Columns {
+		if column.IsComparable() && column.PGType != model.TypePGHstore && searchParamAllowed(entity.Entity, column, "eq", options) {
+			add(column.PGName, "&search."+column.GoName)
+		}
+	}
+
+	for _, filter := range entity.Filters {
+		if !searchParamAllowed(entity.Entity, filter.Column, filter.Op, options) {
+			continue
+		}
+
+		target := "&search." + filter.GoName
+		if filter.Op == "contains" && filter.Column.PGType == model.TypePGJSONB {
+			target = fmt.Sprintf("jsonParam(%s)", target)
+		}
+		add(fmt.Sprintf("%s[%s]", filter.Column.PGName, filter.Op), target)
+	}
+
+	columns := map[string]bool{}
+	for _, column := range entity.Columns {
+		columns[column.PGName] = true
+	}
+	if !columns["sort"] {
+		add("sort", fmt.Sprintf("func(value string) (err error) { search.%s, err = Parse%sSort(value); return err }", entity.SortField, entity.GoName))
+	}
+	if !columns["fields"] {
+		add("fields", fmt.Sprintf("func(value string) (err error) { search.%s, err = Parse%sFields(value); return err }", entity.FieldsField, entity.GoName))
+	}
+
+	return params
+}
+
+// searchParamAllowed checks if operator is allowed for column in query parameters
+// every parameter is allowed if allow-list is not set
+func searchParamAllowed(entity model.Entity, column TemplateColumn, op string, options Options) bool {
+	if len(options.SearchParams) == 0 {
+		return true
+	}
+
+	ops, ok := columnOverride(options.SearchParams, entity.PGSchema, entity.PGName, column.PGName)
+	if !ok {
+		return false
+	}
+
+	for _, allowed := range strings.Fields(ops) {
+		if allowed == "*" || allowed == op {
+			return true
+		}
+	}
+
+	return false
+}
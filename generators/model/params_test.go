@@ -0,0 +1,64 @@
+package model
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/ant31/bungen/model"
+	"github.com/ant31/bungen/util"
+)
+
+func Test_newTemplateParams(t *testing.T) {
+	columns := []model.Column{
+		model.NewColumn("userId", model.TypePGInt8, false, false, false, 0, true, false, 0, nil, nil),
+		model.NewColumn("email", model.TypePGVarchar, false, false, false, 0, false, false, 0, nil, nil),
+		model.NewColumn("meta", model.TypePGJSONB, true, false, false, 0, false, false, 0, nil, nil),
+		model.NewColumn("sort", model.TypePGInt4, false, false, false, 0, false, false, 0, nil, nil),
+	}
+	entity := model.NewEntity(util.PublicSchema, "users", columns, nil)
+
+	tests := []struct {
+		name    string
+		options Options
+		want    []string
+	}{
+		{
+			name:    "Should allow every parameter without allow-list",
+			options: Options{},
+			want: []string{
+				"userId", "email", "sort",
+				"userId[in]", "userId[not_in]", "userId[gt]", "userId[gte]", "userId[lt]", "userId[lte]",
+				"email[in]", "email[not_in]", "email[ilike]", "email[prefix]",
+				"meta[has_key]", "meta[contains]", "meta[is_null]",
+				"sort[in]", "sort[not_in]", "sort[gt]", "sort[gte]", "sort[lt]", "sort[lte]",
+				"fields",
+			},
+		},
+		{
+			name: "Should allow only listed operators",
+			options: Options{SearchParams: map[string]string{
+				"public.users.email": "eq prefix",
+				"users.meta":         "*",
+			}},
+			want: []string{
+				"email",
+				"email[prefix]",
+				"meta[has_key]", "meta[contains]", "meta[is_null]",
+				"fields",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			params := NewTemplateEntity(entity, tt.options).Params
+
+			names := make([]string, len(params))
+			for i, param := range params {
+				names[i] = param.Name
+			}
+			if !reflect.DeepEqual(names, tt.want) {
+				t.Errorf("NewTemplateEntity().Params = %v, want %v", names, tt.want)
+			}
+		})
+	}
+}
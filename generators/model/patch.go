@@ -0,0 +1,74 @@
+package model
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ant31/bungen/model"
+	"github.com/ant31/bungen/util"
+)
+
+// TemplatePatchColumn stores column which can be changed by partial update
+type TemplatePatchColumn struct {
+	TemplateColumn
+
+	// PatchType is a type of patch field, nil or not set value means column is not set
+	PatchType string
+	// Deref is set if patch field is a pointer to column value
+	Deref bool
+	// Optional is set if patch field of nullable column is wrapped into Optional, so it can set NULL
+	Optional bool
+	// PatchTag is a json tag of patch field, set with json tags of models
+	PatchTag string
+}
+
+// newTemplatePatchColumns gets columns compared by Diff and set by patch
+// primary key, generated, unsupported, version and soft delete columns are never updated by value of model,
+// columns filled by hook are set on every update
+func newTemplatePatchColumns(columns []TemplateColumn, hook *TemplateHook, options Options) []TemplatePatchColumn {
+	filled := hookColumns(hook)
+
+	var result []TemplatePatchColumn
+	for _, column := range columns {
+		if column.IsPK || column.IsGenerated || column.Version || column.SoftDelete != "" || column.GoType == model.TypeInterface {
+			continue
+		}
+		if contains(filled, column.PGName) {
+			continue
+		}
+
+		patch := TemplatePatchColumn{TemplateColumn: column, PatchType: column.Type}
+		switch {
+		// nil can't tell NULL from not set value
+		case column.Nullable:
+			patch.PatchType = "Optional[" + column.Type + "]"
+			patch.Optional = true
+		// pointers, slices and maps are nil already
+		case !strings.HasPrefix(column.Type, "*") && !strings.HasPrefix(column.Type, "[]") && !strings.HasPrefix(column.Type, "map["):
+			patch.PatchType = "*" + column.Type
+			patch.Deref = true
+		}
+		if options.AddJSONTag {
+			patch.PatchTag = fmt.Sprintf("`json:\"%s,omitempty\"`", util.Underscore(column.PGName))
+		}
+		result = append(result, patch)
+	}
+
+	return result
+}
+
+// hookColumns gets names of columns filled by hook
+func hookColumns(hook *TemplateHook) []string {
+	if hook == nil {
+		return nil
+	}
+
+	var names []string
+	for _, columns := range [][]TemplateHookColumn{hook.CreatedAt, hook.UpdatedAt, hook.CreatedBy, hook.UpdatedBy} {
+		for _, column := range columns {
+			names = append(names, column.PGName)
+		}
+	}
+
+	return names
+}
@@ -0,0 +1,79 @@
+package model
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ant31/bungen/generators/model/templates"
+	"github.com/ant31/bungen/model"
+	"github.com/ant31/bungen/util"
+)
+
+func patchTestEntity() model.Entity {
+	generated := model.NewColumn("search", model.TypePGText, true, false, false, 0, false, false, 0, nil, nil)
+	generated.IsGenerated = true
+
+	return model.NewEntity(util.PublicSchema, "users", []model.Column{
+		model.NewColumn("id", model.TypePGInt8, false, false, false, 0, true, false, 0, nil, nil),
+		model.NewColumn("email", model.TypePGText, false, false, false, 0, false, false, 0, nil, nil),
+		model.NewColumn("name", model.TypePGText, true, false, false, 0, false, false, 0, nil, nil),
+		model.NewColumn("tags", model.TypePGText, true, false, true, 1, false, false, 0, nil, nil),
+		model.NewColumn("version", model.TypePGInt4, false, false, false, 0, false, false, 0, nil, nil),
+		model.NewColumn("updated_at", model.TypePGTimestamptz, false, false, false, 0, false, false, 0, nil, nil),
+		model.NewColumn("deleted_at", model.TypePGTimestamptz, true, false, false, 0, false, false, 0, nil, nil),
+		generated,
+	}, nil)
+}
+
+func Test_newTemplatePatchColumns(t *testing.T) {
+	options := Options{VersionNames: []string{"version"}, SoftDeleteNames: []string{"deleted_at"}, WithHooks: true, UpdatedAt: []string{"updated_at"}, AddJSONTag: true}
+
+	columns := NewTemplateEntity(patchTestEntity(), options).PatchColumns
+	result := make([]string, len(columns))
+	for i, column := range columns {
+		result[i] = column.GoName + " " + column.PatchType + " " + column.PatchTag
+	}
+
+	want := "Email *string `json:\"email,omitempty\"`; Name Optional[*string] `json:\"name,omitempty\"`; Tags Optional[[]string] `json:\"tags,omitempty\"`"
+	if got := strings.Join(result, "; "); got != want {
+		t.Errorf("newTemplatePatchColumns() = %s, want %s", got, want)
+	}
+	if !columns[0].Deref || columns[1].Deref || columns[0].Optional || !columns[1].Optional {
+		t.Errorf("newTemplatePatchColumns() deref = %v, %v, optional = %v, %v", columns[0].Deref, columns[1].Deref, columns[0].Optional, columns[1].Optional)
+	}
+}
+
+func TestPatchTemplates(t *testing.T) {
+	options := Options{Package: "model", WithORM: true, WithHooks: true, DBWrapName: "DBWrap"}
+	options.Def()
+
+	models := renderTemplate(t, templates.Model, []model.Entity{patchTestEntity()}, options)
+	for _, want := range []string{
+		"func (UserRepo) Diff(old, new *User) []string {\n\tvar columns []string\n\tif changed(old.Email, new.Email) {\n\t\tcolumns = append(columns, Columns.User.Email)\n\t}\n",
+		"\tcolumns := UserRepo{}.Diff(old, new)\n\tif len(columns) == 0 {\n\t\treturn nil\n\t}\n\n\treturn UserRepo{}.Update(ctx, db, new, columns...)\n",
+		"type UserPatch struct {\n\tEmail *string\n\tName  Optional[*string]\n\tTags  Optional[[]string]\n}",
+		"\tif p.Email != nil {\n\t\tm.Email = *p.Email\n",
+		"\tif p.Name.Set {\n\t\tm.Name = p.Name.Value\n",
+		"\t\tq = q.Column(columns...).Column(Columns.User.Version).Column(Columns.User.UpdatedAt)\n",
+		"func (UserRepo) Patch(ctx context.Context, db bun.IDB, m *User, patch *UserPatch) error {",
+	} {
+		if !strings.Contains(models, want) {
+			t.Errorf("generated models do not contain %q", want)
+		}
+	}
+}
+
+func TestPatchTemplates_optional(t *testing.T) {
+	options := Options{Package: "model", WithORM: true, DBWrapName: "DBWrap"}
+	options.Def()
+
+	orm := renderTemplate(t, templates.ORM, []model.Entity{patchTestEntity()}, options)
+	for _, want := range []string{
+		"type Optional[T any] struct {\n\tSet   bool\n\tValue T\n}",
+		"func (o *Optional[T]) UnmarshalJSON(data []byte) error {\n\to.Set = true\n",
+	} {
+		if !strings.Contains(orm, want) {
+			t.Errorf("generated orm helpers do not contain %q", want)
+		}
+	}
+}
@@ -0,0 +1,219 @@
+package model
+
+import (
+	"fmt"
+	"html/template"
+	"strings"
+
+	"github.com/ant31/bungen/model"
+	"github.com/ant31/bungen/util"
+)
+
+// TemplateFilter stores operator filter field of search struct
+type TemplateFilter struct {
+	// GoName is a field name, e.g. EmailIn or CreatedAtGte
+	GoName string
+	Type   string
+	// Render is a code applying filter to query
+	Render template.HTML
+
+	// Column and Op are used to parse filter from query parameter, e.g. email[in]
+	Column TemplateColumn
+	Op     string
+}
+
+// filterOps are query parameter operators of filters
+var filterOps = map[string]string{
+	"In":       "in",
+	"NotIn":    "not_in",
+	"Gt":       "gt",
+	"Gte":      "gte",
+	"Lt":       "lt",
+	"Lte":      "lte",
+	"ILike":    "ilike",
+	"Prefix":   "prefix",
+	"IsNull":   "is_null",
+	"Contains": "contains",
+	"Overlaps": "overlaps",
+	"HasKey":   "has_key",
+}
+
+// newTemplateFilters generates operator filters appropriate to columns types
+func newTemplateFilters(entity model.Entity, columns []TemplateColumn, options Options) []TemplateFilter {
+	reserved := []string{"search"}
+	for _, column := range columns {
+		reserved = append(reserved, column.GoName)
+	}
+
+	var filters []TemplateFilter
+	add := func(column TemplateColumn, op, typ, format string) {
+		name := model.Safe(column.GoName+op, reserved)
+		reserved = append(reserved, name)
+
+		table := entity.GoName + "T.Table.Ref()"
+		field := fmt.Sprintf("Columns.%s.%s", entity.GoName, column.GoName)
+
+		filters = append(filters, TemplateFilter{
+			GoName: name,
+			Type:   typ,
+			Render: template.HTML(fmt.Sprintf(format, table, field, "s."+name)),
+
+			Column: column,
+			Op:     filterOps[op],
+		})
+	}
+
+	for _, column := range columns {
+		if column.GoType == model.TypeInterface {
+			continue
+		}
+
+		value, list := column.SearchType, "[]"+column.GoType
+		if options.Relaxed {
+			value, list = model.TypeInterface, model.TypeInterface
+		}
+
+		switch {
+		case column.IsArray:
+			array := column.Type
+			if options.Relaxed {
+				array = model.TypeInterface
+			}
+			add(column, "Contains", array, `s.op(query, %s, %s, "@>", pgdialect.Array(%s))`)
+			add(column, "Overlaps", array, `s.op(query, %s, %s, "&&", pgdialect.Array(%s))`)
+		case column.PGType == model.TypePGJSONB:
+			add(column, "HasKey", "*string", `s.hasKey(query, %s, %s, *%s)`)
+			add(column, "Contains", model.TypeInterface, `s.op(query, %s, %s, "@>", %s)`)
+		case column.IsComparable() && column.Composite == nil && column.PGType != model.TypePGBool && column.PGType != model.TypePGHstore && column.PGType != model.TypePGBytea:
+			add(column, "In", list, `s.in(query, %s, %s, %s, false)`)
+			add(column, "NotIn", list, `s.in(query, %s, %s, %s, true)`)
+
+			if isOrdered(column.PGType) {
+				for _, op := range []struct{ name, sql string }{{"Gt", ">"}, {"Gte", ">="}, {"Lt", "<"}, {"Lte", "<="}} {
+					add(column, op.name, value, `s.op(query, %s, %s, "`+op.sql+`", %s)`)
+				}
+			}
+			if isText(column.PGType) {
+				add(column, "ILike", "*string", `s.op(query, %s, %s, "ILIKE", *%s)`)
+				add(column, "Prefix", "*string", `s.op(query, %s, %s, "LIKE", likePrefix(*%s))`)
+			}
+		}
+
+		if column.Nullable {
+			add(column, "IsNull", "*bool", `s.null(query, %s, %s, *%s)`)
+		}
+	}
+
+	return filters
+}
+
+// newSearchImports gets imports of search structs and parsers generated apart from models
+// nullable types are not used by search structs, so imports of go types are used
+func newSearchImports(columns []TemplateColumn, filters []TemplateFilter, options Options) []string {
+	imports := util.NewSet()
+	for _, imp := range []string{"fmt", "net/url", "strings", bunImport} {
+		imports.Add(imp)
+	}
+	if usesArrayFilters(filters) {
+		imports.Add(pgdialectImport)
+	}
+	if options.Relaxed {
+		imports.Add("reflect")
+		return imports.Elements()
+	}
+
+	for _, column := range columns {
+		if column.GoType == model.TypeInterface {
+			continue
+		}
+
+		imp := column.Import
+		if imp == "database/sql" || imp == bunImport {
+			imp = model.GoImport(column.PGType, false, false)
+		}
+		if imp != "" {
+			imports.Add(imp)
+		}
+	}
+
+	return imports.Elements()
+}
+
+// usesArrayFilters checks if filters need pgdialect import
+func usesArrayFilters(filters []TemplateFilter) bool {
+	for _, filter := range filters {
+		if strings.Contains(string(filter.Render), "pgdialect.") {
+			return true
+		}
+	}
+	return false
+}
+
+func isOrdered(pgType string) bool {
+	switch pgType {
+	case model.TypePGInt2, model.TypePGInt4, model.TypePGInt8, model.TypePGNumeric, model.TypePGFloat4, model.TypePGFloat8,
+		model.TypePGTimestamp, model.TypePGTimestamptz, model.TypePGDate, model.TypePGTime, model.TypePGTimetz, model.TypePGInterval:
+		return true
+	}
+	return false
+}
+
+func isText(pgType string) bool {
+	return pgType == model.TypePGText || pgType == model.TypePGVarchar || pgType == model.TypePGBpchar
+}
+
+// sortColumns returns columns which can be used in ORDER BY
+func sortColumns(columns []TemplateColumn) []TemplateColumn {
+	var result []TemplateColumn
+	for _, column := range columns {
+		if column.IsComparable() && column.PGType != model.TypePGHstore {
+			result = append(result, column)
+		}
+	}
+	return result
+}
+
+// fieldColumns returns columns which can be selected by projection
+func fieldColumns(columns []TemplateColumn) []TemplateColumn {
+	var result []TemplateColumn
+	for _, column := range columns {
+		if column.GoType != model.TypeInterface {
+			result = append(result, column)
+		}
+	}
+	return result
+}
+
+// keyColumns returns columns always selected with projection: primary key and keyset columns
+func keyColumns(pks []TemplateColumn, pages []TemplatePage) []TemplateColumn {
+	var result []TemplateColumn
+	index := map[string]bool{}
+	add := func(columns []TemplateColumn) {
+		for _, column := range columns {
+			if !index[column.PGName] {
+				index[column.PGName] = true
+				result = append(result, column)
+			}
+		}
+	}
+
+	add(pks)
+	for _, page := range pages {
+		add(page.Columns)
+	}
+
+	return result
+}
+
+// searchFieldName gets name of search struct field which doesn't conflict with columns and filters
+func searchFieldName(name string, columns []TemplateColumn, filters []TemplateFilter) string {
+	reserved := make([]string, 0, len(columns)+len(filters))
+	for _, column := range columns {
+		reserved = append(reserved, column.GoName)
+	}
+	for _, filter := range filters {
+		reserved = append(reserved, filter.GoName)
+	}
+
+	return model.Safe(name, reserved)
+}
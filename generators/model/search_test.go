@@ -0,0 +1,107 @@
+package model
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/ant31/bungen/model"
+	"github.com/ant31/bungen/util"
+)
+
+func Test_newTemplateFilters(t *testing.T) {
+	columns := []model.Column{
+		model.NewColumn("userId", model.TypePGInt8, false, false, false, 0, true, false, 0, nil, nil),
+		model.NewColumn("email", model.TypePGVarchar, false, false, false, 0, false, false, 0, nil, nil),
+		model.NewColumn("emailIn", model.TypePGBool, true, false, false, 0, false, false, 0, nil, nil),
+		model.NewColumn("tags", model.TypePGText, false, false, true, 1, false, false, 0, nil, nil),
+		model.NewColumn("meta", model.TypePGJSONB, false, false, false, 0, false, false, 0, nil, nil),
+	}
+	entity := model.NewEntity(util.PublicSchema, "users", columns, nil)
+
+	tests := []struct {
+		name      string
+		options   Options
+		wantNames []string
+		wantTypes []string
+	}{
+		{
+			name:    "Should generate filters by column types",
+			options: Options{},
+			wantNames: []string{
+				"IDIn", "IDNotIn", "IDGt", "IDGte", "IDLt", "IDLte",
+				"EmailIn1", "EmailNotIn", "EmailILike", "EmailPrefix",
+				"EmailInIsNull",
+				"TagsContains", "TagsOverlaps",
+				"MetaHasKey", "MetaContains",
+			},
+			wantTypes: []string{
+				"[]int64", "[]int64", "*int64", "*int64", "*int64", "*int64",
+				"[]string", "[]string", "*string", "*string",
+				"*bool",
+				"[]string", "[]string",
+				"*string", "interface{}",
+			},
+		},
+		{
+			name:    "Should use interface types in relaxed mode",
+			options: Options{Relaxed: true},
+			wantNames: []string{
+				"IDIn", "IDNotIn", "IDGt", "IDGte", "IDLt", "IDLte",
+				"EmailIn1", "EmailNotIn", "EmailILike", "EmailPrefix",
+				"EmailInIsNull",
+				"TagsContains", "TagsOverlaps",
+				"MetaHasKey", "MetaContains",
+			},
+			wantTypes: []string{
+				"interface{}", "interface{}", "interface{}", "interface{}", "interface{}", "interface{}",
+				"interface{}", "interface{}", "*string", "*string",
+				"*bool",
+				"interface{}", "interface{}",
+				"*string", "interface{}",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filters := NewTemplateEntity(entity, tt.options).Filters
+
+			names := make([]string, len(filters))
+			types := make([]string, len(filters))
+			for i, filter := range filters {
+				names[i] = filter.GoName
+				types[i] = filter.Type
+			}
+
+			if !reflect.DeepEqual(names, tt.wantNames) {
+				t.Errorf("NewTemplateEntity().Filters names = %v, want %v", names, tt.wantNames)
+			}
+			if !reflect.DeepEqual(types, tt.wantTypes) {
+				t.Errorf("NewTemplateEntity().Filters types = %v, want %v", types, tt.wantTypes)
+			}
+		})
+	}
+}
+
+func Test_searchFieldName(t *testing.T) {
+	columns := []model.Column{
+		model.NewColumn("id", model.TypePGInt8, false, false, false, 0, true, false, 0, nil, nil),
+		model.NewColumn("sort", model.TypePGInt4, false, false, false, 0, false, false, 0, nil, nil),
+		model.NewColumn("meta", model.TypePGJSONB, false, false, false, 0, false, false, 0, nil, nil),
+	}
+	entity := NewTemplateEntity(model.NewEntity(util.PublicSchema, "items", columns, nil), Options{})
+
+	if entity.SortField != "Sort1" {
+		t.Errorf("NewTemplateEntity().SortField = %v, want %v", entity.SortField, "Sort1")
+	}
+	if entity.FieldsField != "Fields" {
+		t.Errorf("NewTemplateEntity().FieldsField = %v, want %v", entity.FieldsField, "Fields")
+	}
+
+	sortable := make([]string, len(entity.SortColumns))
+	for i, column := range entity.SortColumns {
+		sortable[i] = column.PGName
+	}
+	if want := []string{"id", "sort"}; !reflect.DeepEqual(sortable, want) {
+		t.Errorf("NewTemplateEntity().SortColumns = %v, want %v", sortable, want)
+	}
+}
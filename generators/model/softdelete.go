@@ -0,0 +1,124 @@
+package model
+
+import (
+	"html/template"
+
+	"github.com/ant31/bungen/model"
+	"github.com/ant31/bungen/util"
+)
+
+// soft delete column kinds
+const (
+	// SoftDeleteTime is a nullable timestamp, NULL for alive rows, it's handled by bun soft_delete tag
+	SoftDeleteTime = "time"
+	// SoftDeleteBool is a not null boolean flag, e.g. is_deleted
+	SoftDeleteBool = "bool"
+	// SoftDeleteUnix is a not null integer unix time of deletion, 0 for alive rows
+	SoftDeleteUnix = "unix"
+
+	// NoSoftDelete disables soft delete of table in options
+	NoSoftDelete = "-"
+)
+
+// TemplateSoftDelete stores soft delete column of entity
+type TemplateSoftDelete struct {
+	Column TemplateColumn
+	Kind   string
+
+	// Alive is a value of column for rows which are not deleted, used in filters of bool and unix kinds
+	Alive template.HTML
+	// Deleted and Restored are values assigned to column on delete and restore, deleted time uses now variable
+	Deleted  template.HTML
+	Restored template.HTML
+	// Update is set if rows are marked deleted by update of column list instead of bun soft delete,
+	// it's always set for bool and unix kinds and for time kind if hook fills columns on update or model has version
+	Update bool
+	// WithDeletedField and OnlyDeletedField are names of search struct options
+	WithDeletedField string
+	OnlyDeletedField string
+}
+
+// IsTime checks if soft delete is handled by bun
+func (s TemplateSoftDelete) IsTime() bool {
+	return s.Kind == SoftDeleteTime
+}
+
+// softDeleteColumn finds soft delete column name of entity
+// table option goes first, then global column, then conventional names
+func softDeleteColumn(entity model.Entity, options Options) string {
+	for _, table := range []string{util.Join(entity.PGSchema, entity.PGName), util.JoinF(entity.PGSchema, entity.PGName)} {
+		if name, ok := options.SoftDeletes[table]; ok {
+			if name == NoSoftDelete {
+				return ""
+			}
+			return name
+		}
+	}
+
+	names := options.SoftDeleteNames
+	if options.SoftDelete != "" {
+		names = []string{options.SoftDelete}
+	}
+	for _, name := range names {
+		for _, column := range entity.Columns {
+			if column.PGName == name && softDeleteKind(column) != "" {
+				return name
+			}
+		}
+	}
+
+	return ""
+}
+
+// softDeleteKind gets kind of soft delete column, empty if column type can't mark deleted rows
+func softDeleteKind(column model.Column) string {
+	switch {
+	case column.IsArray || column.IsGenerated:
+		return ""
+	case column.GoType == model.TypeTime && column.Nullable:
+		return SoftDeleteTime
+	case column.GoType == model.TypeBool && !column.Nullable:
+		return SoftDeleteBool
+	case (column.GoType == model.TypeInt || column.GoType == model.TypeInt32 || column.GoType == model.TypeInt64) && !column.Nullable:
+		return SoftDeleteUnix
+	}
+
+	return ""
+}
+
+// newTemplateSoftDelete creates soft delete info of entity, returns nil if entity has no soft delete column
+func newTemplateSoftDelete(columns []TemplateColumn, filters []TemplateFilter, hook *TemplateHook, version *TemplateColumn) *TemplateSoftDelete {
+	for _, column := range columns {
+		if column.SoftDelete == "" {
+			continue
+		}
+
+		softDelete := TemplateSoftDelete{
+			Column:           column,
+			Kind:             column.SoftDelete,
+			WithDeletedField: searchFieldName("WithDeleted", columns, filters),
+			OnlyDeletedField: searchFieldName("OnlyDeleted", columns, filters),
+		}
+
+		switch softDelete.Kind {
+		case SoftDeleteTime:
+			softDelete.Deleted, softDelete.Restored = "&now", "nil"
+			if column.Type == "bun.NullTime" {
+				softDelete.Deleted, softDelete.Restored = "bun.NullTime{Time: now}", "bun.NullTime{}"
+			}
+			// bun soft delete doesn't call hook with update query and doesn't increment version
+			softDelete.Update = hook != nil && hook.HasUpdate() || version != nil
+		case SoftDeleteBool:
+			softDelete.Alive, softDelete.Deleted, softDelete.Restored = "false", "true", "false"
+			softDelete.Update = true
+		case SoftDeleteUnix:
+			softDelete.Alive, softDelete.Restored = "0", "0"
+			softDelete.Deleted = template.HTML(column.GoType + "(time.Now().Unix())")
+			softDelete.Update = true
+		}
+
+		return &softDelete
+	}
+
+	return nil
+}
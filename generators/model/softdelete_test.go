@@ -0,0 +1,127 @@
+package model
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ant31/bungen/generators/model/templates"
+	"github.com/ant31/bungen/model"
+	"github.com/ant31/bungen/util"
+)
+
+func softDeleteTestEntities() []model.Entity {
+	id := func() model.Column {
+		return model.NewColumn("id", model.TypePGInt8, false, false, false, 0, true, false, 0, nil, nil)
+	}
+
+	return []model.Entity{
+		model.NewEntity(util.PublicSchema, "users", []model.Column{
+			id(),
+			model.NewColumn("is_deleted", model.TypePGBool, false, false, false, 0, false, false, 0, nil, nil),
+		}, nil),
+		model.NewEntity(util.PublicSchema, "projects", []model.Column{
+			id(),
+			model.NewColumn("deleted_at", model.TypePGTimestamptz, true, false, false, 0, false, false, 0, nil, nil),
+		}, nil),
+		model.NewEntity("audit", "logs", []model.Column{
+			id(),
+			model.NewColumn("removed", model.TypePGInt8, false, false, false, 0, false, false, 0, nil, nil),
+			// nullable flag can't tell alive rows from unknown ones
+			model.NewColumn("is_deleted", model.TypePGBool, true, false, false, 0, false, false, 0, nil, nil),
+		}, nil),
+	}
+}
+
+func Test_newTemplateSoftDelete(t *testing.T) {
+	tests := []struct {
+		name    string
+		options Options
+		want    []string
+	}{
+		{
+			name:    "Should detect conventional columns",
+			options: Options{SoftDeleteNames: []string{"deleted_at", "is_deleted"}},
+			want:    []string{"is_deleted bool", "deleted_at time", ""},
+		},
+		{
+			name:    "Should use table column",
+			options: Options{SoftDeleteNames: []string{"deleted_at", "is_deleted"}, SoftDeletes: map[string]string{"audit.logs": "removed", "users": "-"}},
+			want:    []string{"", "deleted_at time", "removed unix"},
+		},
+		{
+			name:    "Should use global column",
+			options: Options{SoftDeleteNames: []string{"deleted_at", "is_deleted"}, SoftDelete: "removed"},
+			want:    []string{"", "", "removed unix"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for i, entity := range softDeleteTestEntities() {
+				got := ""
+				if softDelete := NewTemplateEntity(entity, tt.options).SoftDelete; softDelete != nil {
+					got = softDelete.Column.PGName + " " + softDelete.Kind
+				}
+				if got != tt.want[i] {
+					t.Errorf("newTemplateSoftDelete() %s = %q, want %q", entity.PGName, got, tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestSoftDeleteTemplates(t *testing.T) {
+	options := Options{Package: "model", WithORM: true, WithSearch: true, WithValidation: true, DBWrapName: "DBWrap", SoftDeletes: map[string]string{"audit.logs": "removed"}}
+	options.Def()
+
+	models := renderTemplate(t, templates.Model, softDeleteTestEntities(), options)
+	for _, want := range []string{
+		"`bun:\"is_deleted\"`",
+		"`bun:\"deleted_at,soft_delete\"`",
+		"q := db.NewSelect().Model(m).WherePK().\n\t\tWhere(\"?TableAlias.? = ?\", bun.Ident(Columns.User.IsDeleted), false)",
+		"\t} else {\n\t\tq = q.\n\t\t\tWhere(\"?TableAlias.? = ?\", bun.Ident(Columns.User.IsDeleted), false)\n\t}",
+		"\tm.IsDeleted = true\n\tq := db.NewUpdate().Model(m).Column(Columns.User.IsDeleted).WherePK().",
+		"\tm.DeletedAt = nil\n\tq := db.NewUpdate().Model(m).Column(Columns.Project.DeletedAt).WherePK().WhereDeleted()\n",
+		"\tm.IsDeleted = false\n\tq := db.NewUpdate().Model(m).Column(Columns.User.IsDeleted).WherePK().\n\t\tWhere(\"?TableAlias.? != ?\", bun.Ident(Columns.User.IsDeleted), false)\n",
+		"func (ProjectRepo) Delete(ctx context.Context, db bun.IDB, m *Project) error {\n\tq := db.NewDelete().Model(m).WherePK()\n",
+		"q := db.NewDelete().Model(m).WherePK().ForceDelete()\n",
+		"deleted := int64(time.Now().Unix())",
+		"\tif s.OnlyDeleted {\n\t\tquery.WhereDeleted()\n\t} else if s.WithDeleted {\n\t\tquery.WhereAllWithDeleted()\n\t}",
+		"\t} else if !s.WithDeleted {\n\t\ts.where(query, UserT.Table.Ref(), Columns.User.IsDeleted, false)\n\t}",
+	} {
+		if !strings.Contains(models, want) {
+			t.Errorf("generated models do not contain %q", want)
+		}
+	}
+
+	// false is a value of alive rows, not a missing one
+	if strings.Contains(models, "isZero(m.IsDeleted)") {
+		t.Errorf("generated models require soft delete column")
+	}
+}
+
+func TestSoftDeleteTemplates_hooks(t *testing.T) {
+	options := Options{Package: "model", WithORM: true, WithHooks: true, DBWrapName: "DBWrap"}
+	options.Def()
+
+	updatedAt := func() model.Column {
+		return model.NewColumn("updated_at", model.TypePGTimestamptz, false, false, false, 0, false, false, 0, nil, nil)
+	}
+	entities := softDeleteTestEntities()[:2]
+	entities[0].Columns = append(entities[0].Columns, updatedAt())
+	entities[1].Columns = append(entities[1].Columns, updatedAt())
+
+	models := renderTemplate(t, templates.Model, entities, options)
+	for _, want := range []string{
+		"\tm.IsDeleted = true\n\tq := db.NewUpdate().Model(m).Column(Columns.User.IsDeleted, Columns.User.UpdatedAt).WherePK().",
+		"_, err := db.NewUpdate().Model(&list).Column(Columns.User.IsDeleted, Columns.User.UpdatedAt).Bulk().Exec(ctx)",
+		"\tm.IsDeleted = false\n\tq := db.NewUpdate().Model(m).Column(Columns.User.IsDeleted, Columns.User.UpdatedAt).WherePK().",
+		// bun soft delete doesn't call hook with update query, so deleted time is set by update
+		"\tnow := time.Now()\n\tm.DeletedAt = &now\n\tq := db.NewUpdate().Model(m).Column(Columns.Project.DeletedAt, Columns.Project.UpdatedAt).WherePK()\n",
+		"\tnow := time.Now()\n\tdeleted := &now\n\tfor _, m := range list {\n\t\tm.DeletedAt = deleted\n\t}\n",
+		"\tm.DeletedAt = nil\n\tq := db.NewUpdate().Model(m).Column(Columns.Project.DeletedAt, Columns.Project.UpdatedAt).WherePK().WhereDeleted()\n",
+	} {
+		if !strings.Contains(models, want) {
+			t.Errorf("generated models do not contain %q", want)
+		}
+	}
+}
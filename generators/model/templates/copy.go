@@ -0,0 +1,416 @@
+package templates
+
+const Copy = `//nolint
+//lint:file-ignore U1000 ignore unused code, it's generated
+package {{.Package}}
+
+import (
+	"bytes"
+	"context"
+	"database/sql/driver"
+	"encoding"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"net"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/uptrace/bun"
+	"github.com/uptrace/bun/driver/pgdriver"
+	"github.com/uptrace/bun/schema"
+)
+
+// CopyBatchSize is a maximal number of rows sent by one COPY statement, 0 sends all rows at once
+var CopyBatchSize = 10000
+
+// encodings of COPY columns
+const (
+	copyText = iota
+	copyArray
+	copyJSON
+	copyHstore
+)
+
+// copyColumn is a column written by COPY
+type copyColumn struct {
+	name string
+	kind int
+	// value gets column value of i-th row
+	value func(i int) interface{}
+	// defaulted column is left out of batch if it's zero in all rows, so database default is used
+	defaulted bool
+}
+
+// copyFrom writes count rows into table with COPY FROM STDIN in batches of CopyBatchSize rows
+// returns number of rows written
+func copyFrom(ctx context.Context, conn bun.Conn, table string, count int, columns []copyColumn) (int64, error) {
+	size := CopyBatchSize
+	if size <= 0 {
+		size = count
+	}
+
+	var (
+		total int64
+		buf   bytes.Buffer
+	)
+	fmter := schema.NewFormatter(conn.Dialect())
+	for start := 0; start < count; start += size {
+		end := start + size
+		if end > count {
+			end = count
+		}
+
+		batch := copyBatchColumns(columns, start, end)
+		buf.Reset()
+		for i := start; i < end; i++ {
+			if err := copyRow(&buf, batch, i); err != nil {
+				return total, fmt.Errorf("copy %s row %d: %w", table, i, err)
+			}
+		}
+
+		query := fmter.FormatQuery("COPY ? (?) FROM STDIN", bun.Ident(table), bun.In(copyIdents(batch)))
+		res, err := pgdriver.CopyFrom(ctx, conn, &buf, query)
+		if err != nil {
+			return total, err
+		}
+
+		n, err := res.RowsAffected()
+		if err != nil {
+			return total, err
+		}
+		total += n
+	}
+
+	return total, nil
+}
+
+// copyTo writes columns of all table rows to w with COPY TO STDOUT in text format
+// returns number of rows written
+func copyTo(ctx context.Context, conn bun.Conn, w io.Writer, table string, columns []string) (int64, error) {
+	idents := make([]bun.Ident, len(columns))
+	for i, column := range columns {
+		idents[i] = bun.Ident(column)
+	}
+
+	query := schema.NewFormatter(conn.Dialect()).FormatQuery("COPY ? (?) TO STDOUT", bun.Ident(table), bun.In(idents))
+	res, err := pgdriver.CopyTo(ctx, conn, w, query)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.RowsAffected()
+}
+
+// copyBatchColumns leaves out columns with defaults which are zero in all rows of batch
+func copyBatchColumns(columns []copyColumn, start, end int) []copyColumn {
+	batch := make([]copyColumn, 0, len(columns))
+	for _, column := range columns {
+		if column.defaulted && copyZero(column, start, end) {
+			continue
+		}
+		batch = append(batch, column)
+	}
+
+	// COPY needs at least one column
+	if len(batch) == 0 {
+		return columns
+	}
+
+	return batch
+}
+
+// copyZero checks if column is zero in all rows of batch
+func copyZero(column copyColumn, start, end int) bool {
+	for i := start; i < end; i++ {
+		if v := reflect.ValueOf(column.value(i)); v.IsValid() && !v.IsZero() {
+			return false
+		}
+	}
+
+	return true
+}
+
+// copyIdents gets quoted names of columns
+func copyIdents(columns []copyColumn) []bun.Ident {
+	idents := make([]bun.Ident, len(columns))
+	for i, column := range columns {
+		idents[i] = bun.Ident(column.name)
+	}
+
+	return idents
+}
+
+// copyRow writes i-th row to buf in COPY text format
+func copyRow(buf *bytes.Buffer, columns []copyColumn, i int) error {
+	for j, column := range columns {
+		if j > 0 {
+			buf.WriteByte('\t')
+		}
+
+		text, ok, err := copyEncode(column.kind, column.value(i))
+		if err != nil {
+			return fmt.Errorf("%s: %w", column.name, err)
+		}
+		if !ok {
+			buf.WriteString("\\N")
+			continue
+		}
+		copyEscape(buf, text)
+	}
+	buf.WriteByte('\n')
+
+	return nil
+}
+
+// copyEscape writes text to buf escaping COPY delimiters
+func copyEscape(buf *bytes.Buffer, text string) {
+	for i := 0; i < len(text); i++ {
+		switch c := text[i]; c {
+		case '\\':
+			buf.WriteString("\\\\")
+		case '\n':
+			buf.WriteString("\\n")
+		case '\r':
+			buf.WriteString("\\r")
+		case '\t':
+			buf.WriteString("\\t")
+		default:
+			buf.WriteByte(c)
+		}
+	}
+}
+
+// copyEncode gets postgres text representation of value, false means NULL
+func copyEncode(kind int, v interface{}) (string, bool, error) {
+	if copyNil(v) {
+		return "", false, nil
+	}
+
+	if valuer, ok := v.(driver.Valuer); ok {
+		value, err := valuer.Value()
+		if err != nil || value == nil {
+			return "", false, err
+		}
+		// valuers of json and array types return their text representation already
+		if kind != copyText {
+			switch x := value.(type) {
+			case []byte:
+				return string(x), true, nil
+			case string:
+				return x, true, nil
+			}
+		}
+		v = value
+	}
+
+	switch kind {
+	case copyJSON:
+		return copyJSONText(v)
+	case copyArray:
+		return copyArrayText(reflect.ValueOf(v))
+	case copyHstore:
+		return copyHstoreText(v)
+	}
+
+	return copyValueText(v)
+}
+
+// copyNil checks if value is nil or nil pointer, slice or map
+func copyNil(v interface{}) bool {
+	if v == nil {
+		return true
+	}
+
+	switch rv := reflect.ValueOf(v); rv.Kind() {
+	case reflect.Ptr, reflect.Slice, reflect.Map, reflect.Interface:
+		return rv.IsNil()
+	}
+
+	return false
+}
+
+// copyValueText gets postgres text representation of scalar value
+func copyValueText(v interface{}) (string, bool, error) {
+	if copyNil(v) {
+		return "", false, nil
+	}
+	if rv := reflect.ValueOf(v); rv.Kind() == reflect.Ptr {
+		return copyValueText(rv.Elem().Interface())
+	}
+
+	switch x := v.(type) {
+	case string:
+		return x, true, nil
+	case []byte:
+		return "\\x" + hex.EncodeToString(x), true, nil
+	case bool:
+		if x {
+			return "t", true, nil
+		}
+		return "f", true, nil
+	case time.Time:
+		return x.UTC().Format("2006-01-02 15:04:05.999999-07:00"), true, nil
+	case bun.NullTime:
+		if x.IsZero() {
+			return "", false, nil
+		}
+		return copyValueText(x.Time)
+	case time.Duration:
+		return strconv.FormatInt(x.Microseconds(), 10) + " microseconds", true, nil
+	case net.IPNet:
+		return x.String(), true, nil
+	case driver.Valuer:
+		value, err := x.Value()
+		if err != nil {
+			return "", false, err
+		}
+		return copyValueText(value)
+	case encoding.TextMarshaler:
+		text, err := x.MarshalText()
+		return string(text), err == nil, err
+	}
+
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.String:
+		return rv.String(), true, nil
+	case reflect.Bool:
+		return copyValueText(rv.Bool())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(rv.Int(), 10), true, nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(rv.Uint(), 10), true, nil
+	case reflect.Float32, reflect.Float64:
+		return copyFloatText(rv.Float(), rv.Type().Bits()), true, nil
+	case reflect.Map, reflect.Struct:
+		return copyJSONText(v)
+	}
+
+	return "", false, fmt.Errorf("unsupported type %T", v)
+}
+
+// copyFloatText gets postgres text representation of float
+func copyFloatText(f float64, bits int) string {
+	switch {
+	case math.IsInf(f, 1):
+		return "Infinity"
+	case math.IsInf(f, -1):
+		return "-Infinity"
+	}
+
+	return strconv.FormatFloat(f, 'g', -1, bits)
+}
+
+// copyJSONText gets text of json value, raw json is written as is
+func copyJSONText(v interface{}) (string, bool, error) {
+	switch x := v.(type) {
+	case json.RawMessage:
+		return string(x), true, nil
+	case []byte:
+		return string(x), true, nil
+	case string:
+		return x, true, nil
+	}
+
+	b, err := json.Marshal(v)
+	if err != nil {
+		return "", false, err
+	}
+
+	return string(b), true, nil
+}
+
+// copyArrayText gets postgres array literal of slice, nested slices are written as multidimensional arrays
+func copyArrayText(rv reflect.Value) (string, bool, error) {
+	if rv.Kind() == reflect.Ptr {
+		if rv.IsNil() {
+			return "", false, nil
+		}
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array {
+		return "", false, fmt.Errorf("unsupported array type %s", rv.Type())
+	}
+	if rv.Kind() == reflect.Slice && rv.IsNil() {
+		return "", false, nil
+	}
+
+	var b strings.Builder
+	b.WriteByte('{')
+	for i := 0; i < rv.Len(); i++ {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+
+		elem := rv.Index(i)
+		if kind := elem.Kind(); (kind == reflect.Slice || kind == reflect.Array) && elem.Type().Elem().Kind() != reflect.Uint8 {
+			text, ok, err := copyArrayText(elem)
+			if err != nil {
+				return "", false, err
+			}
+			if !ok {
+				text = "NULL"
+			}
+			b.WriteString(text)
+			continue
+		}
+
+		text, ok, err := copyEncode(copyText, elem.Interface())
+		if err != nil {
+			return "", false, err
+		}
+		if !ok {
+			b.WriteString("NULL")
+			continue
+		}
+		copyQuote(&b, text)
+	}
+	b.WriteByte('}')
+
+	return b.String(), true, nil
+}
+
+// copyHstoreText gets postgres hstore literal of map
+func copyHstoreText(v interface{}) (string, bool, error) {
+	m, ok := v.(map[string]string)
+	if !ok {
+		return "", false, fmt.Errorf("unsupported hstore type %T", v)
+	}
+
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var b strings.Builder
+	for i, key := range keys {
+		if i > 0 {
+			b.WriteString(", ")
+		}
+		copyQuote(&b, key)
+		b.WriteString("=>")
+		copyQuote(&b, m[key])
+	}
+
+	return b.String(), true, nil
+}
+
+// copyQuote writes double quoted element of array or hstore
+func copyQuote(b *strings.Builder, text string) {
+	b.WriteByte('"')
+	for i := 0; i < len(text); i++ {
+		if c := text[i]; c == '"' || c == '\\' {
+			b.WriteByte('\\')
+		}
+		b.WriteByte(text[i])
+	}
+	b.WriteByte('"')
+}
+`
@@ -2,23 +2,247 @@ package templates
 
 const ORM = `//nolint
 //lint:file-ignore U1000 ignore unused code, it's generated
-package {{.Package}}{{if .HasImports}}
+package {{.Package}}
 
-import ({{range .Imports}}
-    "{{.}}"{{end}}
-	{{- if .WithORM}}
+import (
 	"context"
-	{{- end}}
+	"database/sql"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strings"
+	"time"
+
 	"github.com/uptrace/bun"
-){{end}}
+)
 
 
 /* Common ORM queries */
-
-// Just a wrapper around database connection
-{{- if .WithORM}}
+{{if .WithORM}}
+// {{ .ORMDbStruct }} wraps database, connection or transaction, all queries of it are executed with wrapped bun.IDB
 type {{ .ORMDbStruct }} struct {
-	*bun.DB
+	bun.IDB
+}
+
+// New{{ .ORMDbStruct }} wraps database or connection
+func New{{ .ORMDbStruct }}(db bun.IDB) *{{ .ORMDbStruct }} {
+	return &{{ .ORMDbStruct }}{IDB: db}
+}
+
+// WithTx gets wrapper bound to transaction
+func (w *{{ .ORMDbStruct }}) WithTx(tx bun.Tx) *{{ .ORMDbStruct }} {
+	return &{{ .ORMDbStruct }}{IDB: tx}
+}
+
+// RunInTx runs fn with wrapper bound to transaction, commits if fn returns nil and rolls back otherwise
+// called on wrapper bound to transaction it uses savepoint, so nested call can be rolled back alone
+// pass repo.IDB to repositories to run their queries in transaction
+func (w *{{ .ORMDbStruct }}) RunInTx(ctx context.Context, opts *sql.TxOptions, fn func(repo *{{ .ORMDbStruct }}) error) error {
+	return w.IDB.RunInTx(ctx, opts, func(ctx context.Context, tx bun.Tx) error {
+		return fn(w.WithTx(tx))
+	})
 }
 {{- end}}
+
+// Pager limits list queries, zero Limit means no limit
+type Pager struct {
+	Limit  int
+	Offset int
+}
+
+// NewPager creates pager for page number starting from 1
+func NewPager(page, size int) Pager {
+	if page < 1 {
+		page = 1
+	}
+
+	return Pager{Limit: size, Offset: (page - 1) * size}
+}
+
+func (p Pager) apply(q *bun.SelectQuery) *bun.SelectQuery {
+	if p.Limit > 0 {
+		q = q.Limit(p.Limit)
+	}
+	if p.Offset > 0 {
+		q = q.Offset(p.Offset)
+	}
+
+	return q
+}
+
+// ErrInvalidCursor is returned by keyset paginators if cursor can't be decoded
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// Cursor is an opaque position of row in keyset pagination
+type Cursor string
+
+// PageDirection sets which side of cursor is fetched by keyset paginators
+type PageDirection int
+
+const (
+	// PageNext fetches rows after cursor, first page if cursor is empty
+	PageNext PageDirection = iota
+	// PagePrev fetches rows before cursor, last page if cursor is empty
+	PagePrev
+)
+
+// newCursor encodes keyset values of row
+func newCursor(values ...interface{}) (Cursor, error) {
+	data, err := json.Marshal(values)
+	if err != nil {
+		return "", err
+	}
+
+	return Cursor(base64.RawURLEncoding.EncodeToString(data)), nil
+}
+
+// decode decodes keyset values into pointers
+func (c Cursor) decode(values ...interface{}) error {
+	data, err := base64.RawURLEncoding.DecodeString(string(c))
+	if err != nil {
+		return ErrInvalidCursor
+	}
+
+	var raw []json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil || len(raw) != len(values) {
+		return ErrInvalidCursor
+	}
+	for i := range values {
+		if err := json.Unmarshal(raw[i], values[i]); err != nil {
+			return ErrInvalidCursor
+		}
+	}
+
+	return nil
+}
+
+// keyset adds condition on keyset columns if values are set, ordering and limit with one extra row
+// rows are ordered backwards for PagePrev and must be reversed after scan
+func keyset(q *bun.SelectQuery, columns []string, values []interface{}, direction PageDirection, limit int) *bun.SelectQuery {
+	op, order := ">", "ASC"
+	if direction == PagePrev {
+		op, order = "<", "DESC"
+	}
+
+	if values != nil {
+		args := make([]interface{}, 0, len(columns)+2)
+		for _, column := range columns {
+			args = append(args, bun.Ident(column))
+		}
+		args = append(args, bun.Safe(op), bun.In(values))
+
+		q = q.Where("("+strings.TrimSuffix(strings.Repeat("?TableAlias.?, ", len(columns)), ", ")+") ? (?)", args...)
+	}
+
+	for _, column := range columns {
+		q = q.OrderExpr("?TableAlias.? ?", bun.Ident(column), bun.Safe(order))
+	}
+	if limit > 0 {
+		q = q.Limit(limit + 1)
+	}
+
+	return q
+}
+
+// upsert adds ON CONFLICT (conflict) DO UPDATE clause for columns
+// first conflict column is updated if columns are empty, so RETURNING still gets values
+func upsert(q *bun.InsertQuery, conflict, columns []string) *bun.InsertQuery {
+	if len(columns) == 0 {
+		columns = conflict[:1]
+	}
+
+	q = q.On("CONFLICT (?) DO UPDATE", bun.In(idents(conflict)))
+	for _, column := range columns {
+		q = q.Set("? = EXCLUDED.?", bun.Ident(column), bun.Ident(column))
+	}
+
+	return q
+}
+
+func idents(columns []string) []bun.Ident {
+	result := make([]bun.Ident, len(columns))
+	for i, column := range columns {
+		result[i] = bun.Ident(column)
+	}
+	return result
+}
+
+// affected returns sql.ErrNoRows if query changed nothing
+func affected(res sql.Result, err error) error {
+	if err != nil {
+		return err
+	}
+
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+// changed checks if values of model field differ, times are compared as instants
+func changed(old, new interface{}) bool {
+	switch o := old.(type) {
+	case time.Time:
+		return !o.Equal(new.(time.Time))
+	case *time.Time:
+		n := new.(*time.Time)
+		if o == nil || n == nil {
+			return o != n
+		}
+		return !o.Equal(*n)
+	case bun.NullTime:
+		return !o.Time.Equal(new.(bun.NullTime).Time)
+	case sql.NullTime:
+		n := new.(sql.NullTime)
+		return o.Valid != n.Valid || !o.Time.Equal(n.Time)
+	}
+
+	return !reflect.DeepEqual(old, new)
+}
+
+// Optional is a patch field of nullable column, it's set if present in JSON even if it's null, so column can be set to NULL
+type Optional[T any] struct {
+	Set   bool
+	Value T
+}
+
+// NewOptional creates set patch field
+func NewOptional[T any](value T) Optional[T] {
+	return Optional[T]{Set: true, Value: value}
+}
+
+func (o *Optional[T]) UnmarshalJSON(data []byte) error {
+	o.Set = true
+	return json.Unmarshal(data, &o.Value)
+}
+
+func (o Optional[T]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(o.Value)
+}
+
+// ErrStaleObject is returned by queries of models with version column if row was changed or deleted since it was read
+var ErrStaleObject = errors.New("stale object")
+
+// stale returns ErrStaleObject if query changed less rows than expected
+func stale(res sql.Result, err error, rows int) error {
+	if err != nil {
+		return err
+	}
+
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n < int64(rows) {
+		return ErrStaleObject
+	}
+
+	return nil
+}
 `
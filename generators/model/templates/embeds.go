@@ -0,0 +1,17 @@
+package templates
+
+// Embeds is a file with structs of columns shared by several models
+const Embeds = `//nolint
+//lint:file-ignore U1000 ignore unused code, it's generated
+package {{.Package}}
+{{if .EmbedImports}}
+import ({{range .EmbedImports}}
+	"{{.}}"{{end}}
+)
+{{end}}
+{{range .Embeds}}
+// {{.Name}} contains columns shared by {{range $i, $e := .Entities}}{{if $i}}, {{end}}{{.}}{{end}}
+type {{.Name}} struct { {{- range .Columns}}
+	{{.GoName}} {{.Type}} {{.Tag}} {{.Comment}}{{end}}
+}
+{{end}}`
@@ -0,0 +1,98 @@
+package templates
+
+const Errors = `//nolint
+//lint:file-ignore U1000 ignore unused code, it's generated
+package {{.Package}}
+
+import (
+	"errors"
+
+	"github.com/uptrace/bun/driver/pgdriver"
+)
+
+// ConstraintKind is a type of table constraint
+type ConstraintKind string
+
+const (
+	ConstraintPK        ConstraintKind = "primary_key"
+	ConstraintUnique    ConstraintKind = "unique"
+	ConstraintFK        ConstraintKind = "foreign_key"
+	ConstraintCheck     ConstraintKind = "check"
+	ConstraintExclusion ConstraintKind = "exclusion"
+)
+
+// Constraint is a sentinel error of table constraint violation, compare it with errors.Is after TranslateError
+type Constraint struct {
+	Schema  string
+	Table   string
+	Name    string
+	Kind    ConstraintKind
+	Columns []string
+}
+
+func (c *Constraint) Error() string {
+	return "violates " + string(c.Kind) + " constraint " + c.Name + " of " + c.Table
+}
+
+// ConstraintError wraps database error caused by constraint violation
+type ConstraintError struct {
+	*Constraint
+	Err error
+}
+
+func (e *ConstraintError) Error() string {
+	return e.Err.Error()
+}
+
+// Is matches sentinel error of violated constraint
+func (e *ConstraintError) Is(target error) bool {
+	return target == e.Constraint
+}
+
+func (e *ConstraintError) Unwrap() error {
+	return e.Err
+}
+
+{{range $model := .Entities}}{{if .Constraints}}
+// {{.GoName}} constraints
+var ({{range .Constraints}}
+	{{.Var}} = &Constraint{Schema: {{printf "%q" $model.PGSchema}}, Table: {{printf "%q" $model.PGName}}, Name: {{printf "%q" .Name}}, Kind: {{.Kind}}, Columns: []string{ {{- range $i, $e := .Columns}}{{if $i}}, {{end}}{{printf "%q" .}}{{end -}} }}{{end}}
+)
+{{end}}{{end}}
+// constraints are sentinel errors by schema, table and constraint name
+var constraints = map[[3]string]*Constraint{ {{- range $model := .Entities}}{{range .Constraints}}
+	{ {{- printf "%q" $model.PGSchema}}, {{printf "%q" $model.PGName}}, {{printf "%q" .Name -}} }: {{.Var}},{{end}}{{end}}
+}
+
+// integrityCodes are SQLSTATE codes of constraint violations and kinds of constraints reporting them
+// unique_violation is reported for primary keys as well
+var integrityCodes = map[string][]ConstraintKind{
+	"23505": {ConstraintUnique, ConstraintPK},
+	"23503": {ConstraintFK},
+	"23514": {ConstraintCheck},
+	"23P01": {ConstraintExclusion},
+}
+
+// TranslateError wraps error caused by known constraint violation into ConstraintError, other errors are returned as is
+//
+//	if err := TranslateError(err); errors.Is(err, ErrUserEmailUnique) { ... }
+func TranslateError(err error) error {
+	var pgErr pgdriver.Error
+	if !errors.As(err, &pgErr) {
+		return err
+	}
+
+	constraint, ok := constraints[[3]string{pgErr.Field('s'), pgErr.Field('t'), pgErr.Field('n')}]
+	if !ok {
+		return err
+	}
+
+	for _, kind := range integrityCodes[pgErr.Field('C')] {
+		if kind == constraint.Kind {
+			return &ConstraintError{Constraint: constraint, Err: err}
+		}
+	}
+
+	return err
+}
+`
@@ -0,0 +1,27 @@
+package templates
+
+// Hooks is a file with context key of user filled into audit columns by BeforeAppendModel hooks
+const Hooks = `//nolint
+//lint:file-ignore U1000 ignore unused code, it's generated
+package {{.Package}}
+
+import (
+	"context"{{if .AuditKeyImport}}
+
+	"{{.AuditKeyImport}}"{{end}}
+)
+{{if .AuditKey}}
+// AuditKey is a context key of user filled into created_by and updated_by columns by hooks
+var AuditKey interface{} = {{.AuditKey}}
+{{else}}
+type auditKey struct{}
+
+// AuditKey is a context key of user filled into created_by and updated_by columns by hooks
+var AuditKey interface{} = auditKey{}
+{{end}}
+// WithAuditUser returns context with user filled into created_by and updated_by columns by hooks,
+// user must have the same type as columns
+func WithAuditUser(ctx context.Context, user interface{}) context.Context {
+	return context.WithValue(ctx, AuditKey, user)
+}
+`
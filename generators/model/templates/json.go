@@ -0,0 +1,15 @@
+package templates
+
+const JSON = `//nolint
+//lint:file-ignore U1000 ignore unused code, it's generated
+package {{.Package}}{{if .JSONImports}}
+
+import ({{range .JSONImports}}
+	"{{.}}"{{end}}
+){{end}}
+{{range .JSONStructs}}
+type {{.GoName}} struct { {{range .Fields}}
+	{{.GoName}} {{.Type}} {{.Tag}}{{end}}
+}
+{{end}}
+`
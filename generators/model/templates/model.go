@@ -2,81 +2,696 @@ package templates
 
 const Model = `//nolint
 //lint:file-ignore U1000 ignore unused code, it's generated
-package {{.Package}}{{if .HasImports}}
+package {{.Package}}
 
 import ({{range .Imports}}
     "{{.}}"{{end}}
-	{{- if .WithORM}}
+	{{- if or .WithORM .WithCopy}}
 	"context"
 	{{- end}}
 	"github.com/uptrace/bun"
-){{end}}
+)
 
 
 {{range $model := .Entities}}
 type {{.GoName}} struct {
 	bun.BaseModel {{.Tag}}
+	{{range .Embeds}}
+	{{.}}{{end}}
 
-	{{range .Columns}}
-	{{.GoName}} {{.Type}} {{.Tag}} {{.Comment}}{{end}}{{if .HasRelations}}
+	{{range .Columns}}{{if not .Embed}}
+	{{.GoName}} {{.Type}} {{.Tag}} {{.Comment}}{{end}}{{end}}{{if .HasRelations}}
 	{{range .Relations}}
 	{{.GoName}} *{{.GoType}} {{.Tag}} {{.Comment}}{{end}}{{end}}
 }
-{{end}}
+{{if gt (len .PKs) 1}}
+// {{.GoName}}PK is a composite primary key of {{.GoName}}
+type {{.GoName}}PK struct { {{range .PKs}}
+	{{.GoName}} {{.Type}}{{end}}
+}
+
+// PK gets primary key of {{.GoName}}
+func (m *{{.GoName}}) PK() {{.GoName}}PK {
+	return {{.GoName}}PK{ {{- range $i, $e := .PKs}}{{if $i}}, {{end}}{{.GoName}}: m.{{.GoName}}{{end -}} }
+}
+{{end}}{{with .Hook}}
+var _ bun.BeforeAppendModelHook = (*{{$model.GoName}})(nil)
+
+// BeforeAppendModel fills timestamp and audit columns of {{$model.GoName}} on insert and update
+func (m *{{$model.GoName}}) BeforeAppendModel(ctx context.Context, query bun.Query) error {
+	switch query.(type) {
+	case *bun.InsertQuery:{{if .HasTimes}}
+		now := time.Now(){{end}}{{range .CreatedAt}}
+		if {{.Empty}} {
+			m.{{.GoName}} = {{.Value}}
+		}{{end}}{{range .UpdatedAt}}
+		m.{{.GoName}} = {{.Value}}{{end}}{{if .HasUsers}}
+		if user, ok := ctx.Value(AuditKey).({{.UserType}}); ok { {{- range .CreatedBy}}
+			m.{{.GoName}} = {{.Value}}{{end}}{{range .UpdatedBy}}
+			m.{{.GoName}} = {{.Value}}{{end}}
+		}{{end}}{{if .HasUpdate}}
+	case *bun.UpdateQuery:{{if .UpdatedAt}}
+		now := time.Now(){{end}}{{range .UpdatedAt}}
+		m.{{.GoName}} = {{.Value}}{{end}}{{if .UpdatedBy}}
+		if user, ok := ctx.Value(AuditKey).({{.UserType}}); ok { {{- range .UpdatedBy}}
+			m.{{.GoName}} = {{.Value}}{{end}}
+		}{{end}}{{end}}
+	}
+
+	return nil
+}
+{{end}}{{end}}
 
 /* Common ORM queries */
 
 {{- if .WithORM}}
 {{$dbstruct := .}}
-/* 'SELECT' queries */
 {{- range $model := .Entities}}
+// {{.GoName}}Repo contains queries for {{.GoName}}
+// every method accepts bun.IDB, so it can be used with bun.DB, bun.Conn or bun.Tx
+type {{.GoName}}Repo struct{}
+{{if eq (len .PKs) 1}}{{with index .PKs 0}}
+// GetByPK gets {{$model.GoName}} by primary key, returns sql.ErrNoRows if not found
+func ({{$model.GoName}}Repo) GetByPK(ctx context.Context, db bun.IDB, pk {{.Type}}, relations ...string) (*{{$model.GoName}}, error) {
+	m := &{{$model.GoName}}{ {{- template "pkField" .}}}
+	q := db.NewSelect().Model(m).WherePK(){{template "alive" $model}}
+	for _, relation := range relations {
+		q = q.Relation(relation)
+	}
+
+	if err := q.Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	return m, nil
+}
+
+// GetByPKs gets list of {{$model.GoName}} by primary keys
+func ({{$model.GoName}}Repo) GetByPKs(ctx context.Context, db bun.IDB, pks []{{.Type}}, relations ...string) ([]*{{$model.GoName}}, error) {
+	list := []*{{$model.GoName}}{}
+	if len(pks) == 0 {
+		return list, nil
+	}
+
+	q := db.NewSelect().Model(&list).Where("?TableAlias.? IN (?)", bun.Ident(Columns.{{$model.GoName}}.{{.GoName}}), bun.In(pks)){{template "alive" $model}}
+	for _, relation := range relations {
+		q = q.Relation(relation)
+	}
+
+	err := q.Scan(ctx)
+	return list, err
+}
+
+// DeleteByPK {{if $model.SoftDelete}}marks {{$model.GoName}} deleted{{else}}deletes {{$model.GoName}}{{end}} by primary key{{if $model.Version}} and version{{end}}, returns {{template "notFound" $model}} if nothing deleted
+func ({{$model.GoName}}Repo) DeleteByPK(ctx context.Context, db bun.IDB, pk {{.Type}}{{with $model.Version}}, version {{.Type}}{{end}}) error { {{- if $model.Version}}
+	m := &{{$model.GoName}}{ {{- template "pkField" .}}}
+	m.{{$model.Version.GoName}} = version
+	return {{$model.GoName}}Repo{}.Delete(ctx, db, m){{else if $model.SoftDelete}}
+	return {{$model.GoName}}Repo{}.Delete(ctx, db, &{{$model.GoName}}{ {{- template "pkField" .}}}){{else}}
+	return affected(db.NewDelete().Model(&{{$model.GoName}}{ {{- template "pkField" .}}}).WherePK().Exec(ctx)){{end}}
+}
+{{end}}{{else if gt (len .PKs) 1}}
+// GetByPK gets {{.GoName}} by composite primary key, returns sql.ErrNoRows if not found
+func ({{.GoName}}Repo) GetByPK(ctx context.Context, db bun.IDB, pk {{.GoName}}PK, relations ...string) (*{{.GoName}}, error) {
+	m := &{{.GoName}}{ {{- range $i, $e := .PKs}}{{if $i}}, {{end}}{{.GoName}}: pk.{{.GoName}}{{end -}} }
+	q := db.NewSelect().Model(m).WherePK(){{template "alive" $model}}
+	for _, relation := range relations {
+		q = q.Relation(relation)
+	}
+
+	if err := q.Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	return m, nil
+}
+
+// GetByPKs gets list of {{.GoName}} by composite primary keys
+func ({{.GoName}}Repo) GetByPKs(ctx context.Context, db bun.IDB, pks []{{.GoName}}PK, relations ...string) ([]*{{.GoName}}, error) {
+	list := []*{{.GoName}}{}
+	if len(pks) == 0 {
+		return list, nil
+	}
+
+	values := make([][]interface{}, len(pks))
+	for i, pk := range pks {
+		values[i] = []interface{}{ {{- range $i, $e := .PKs}}{{if $i}}, {{end}}pk.{{.GoName}}{{end -}} }
+	}
+
+	q := db.NewSelect().Model(&list).
+		Where("({{range $i, $e := .PKs}}{{if $i}}, {{end}}?TableAlias.?{{end}}) IN (?)", {{range .PKs}}bun.Ident(Columns.{{$model.GoName}}.{{.GoName}}), {{end}}bun.In(values)){{template "alive" $model}}
+	for _, relation := range relations {
+		q = q.Relation(relation)
+	}
+
+	err := q.Scan(ctx)
+	return list, err
+}
+
+// DeleteByPK {{if .SoftDelete}}marks {{.GoName}} deleted{{else}}deletes {{.GoName}}{{end}} by composite primary key{{if .Version}} and version{{end}}, returns {{template "notFound" .}} if nothing deleted
+func ({{.GoName}}Repo) DeleteByPK(ctx context.Context, db bun.IDB, pk {{.GoName}}PK{{with .Version}}, version {{.Type}}{{end}}) error {
+	m := &{{.GoName}}{ {{- range $i, $e := .PKs}}{{if $i}}, {{end}}{{.GoName}}: pk.{{.GoName}}{{end -}} }{{with .Version}}
+	m.{{.GoName}} = version{{end}}{{if or .SoftDelete .Version}}
+	return {{.GoName}}Repo{}.Delete(ctx, db, m){{else}}
+	return affected(db.NewDelete().Model(m).WherePK().Exec(ctx)){{end}}
+}
+{{end}}{{range .Finders}}{{if .Unique}}
+// {{.Name}} gets {{$model.GoName}} by unique index {{.Index}}, returns sql.ErrNoRows if not found
+func ({{$model.GoName}}Repo) {{.Name}}(ctx context.Context, db bun.IDB, {{range .Columns}}{{.ParamName}} {{.ParamType}}, {{end}}relations ...string) (*{{$model.GoName}}, error) {
+	m := &{{$model.GoName}}{}
+	q := db.NewSelect().Model(m){{range .Columns}}.
+		Where("?TableAlias.? = ?", bun.Ident(Columns.{{$model.GoName}}.{{.GoName}}), {{.ParamName}}){{end}}{{template "alive" $model}}
+	for _, relation := range relations {
+		q = q.Relation(relation)
+	}
+
+	if err := q.Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	return m, nil
+}
+{{else}}
+// {{.Name}} gets list of {{$model.GoName}} using index {{.Index}}, ordered by primary key
+func ({{$model.GoName}}Repo) {{.Name}}(ctx context.Context, db bun.IDB, {{range .Columns}}{{.ParamName}} {{.ParamType}}, {{end}}pager Pager, relations ...string) ([]*{{$model.GoName}}, error) {
+	list := []*{{$model.GoName}}{}
+	q := db.NewSelect().Model(&list){{range .Columns}}.
+		Where("?TableAlias.? = ?", bun.Ident(Columns.{{$model.GoName}}.{{.GoName}}), {{.ParamName}}){{end}}{{template "alive" $model}}{{range $model.PKs}}.
+		OrderExpr("?TableAlias.?", bun.Ident(Columns.{{$model.GoName}}.{{.GoName}})){{end}}
+	for _, relation := range relations {
+		q = q.Relation(relation)
+	}
+
+	err := pager.apply(q).Scan(ctx)
+	return list, err
+}
+{{end}}{{end}}
+// List gets list of {{.GoName}}{{if $dbstruct.WithSearch}} filtered and sorted by search{{end}}, ordered by primary key{{if $dbstruct.WithSearch}} after search sort{{end}}
+func ({{.GoName}}Repo) List(ctx context.Context, db bun.IDB, {{if $dbstruct.WithSearch}}search *{{.GoName}}Search, {{end}}pager Pager, relations ...string) ([]*{{.GoName}}, error) {
+	list := []*{{.GoName}}{}
+	q := db.NewSelect().Model(&list)
+	for _, relation := range relations {
+		q = q.Relation(relation)
+	}{{if $dbstruct.WithSearch}}
+	if search != nil {
+		q = q.ApplyQueryBuilder(search.Apply)
+	}{{template "aliveElse" $model}}{{else}}{{template "aliveQuery" $model}}{{end}}
+	{{- range .PKs}}
+	q = q.OrderExpr("?TableAlias.?", bun.Ident(Columns.{{$model.GoName}}.{{.GoName}})){{end}}
+
+	err := pager.apply(q).Scan(ctx)
+	return list, err
+}
+
+{{if .Pages}}// {{.GoName}}Page is a page of {{.GoName}} fetched by keyset paginator
+// Next and Prev cursors are empty if there are no rows after or before the page
+type {{.GoName}}Page struct {
+	Items []*{{.GoName}}
+	Next  Cursor
+	Prev  Cursor
+}
+{{range .Pages}}
+// Page{{.Name}} gets page of {{$model.GoName}}{{if $dbstruct.WithSearch}} filtered by search{{end}} using keyset pagination over {{.Index}}
+// rows are ordered by {{range $i, $e := .Columns}}{{if $i}}, {{end}}{{.PGName}}{{end}}, empty cursor gets the first page or the last one with PagePrev
+func ({{$model.GoName}}Repo) Page{{.Name}}(ctx context.Context, db bun.IDB, {{if $dbstruct.WithSearch}}search *{{$model.GoName}}Search, {{end}}cursor Cursor, limit int, direction PageDirection, relations ...string) (*{{$model.GoName}}Page, error) {
+	var values []interface{}
+	if cursor != "" {
+		var ({{range .Columns}}
+			{{.ParamName}} {{.ParamType}}{{end}}
+		)
+		if err := cursor.decode({{range $i, $e := .Columns}}{{if $i}}, {{end}}&{{.ParamName}}{{end}}); err != nil {
+			return nil, err
+		}
+		values = []interface{}{ {{- range $i, $e := .Columns}}{{if $i}}, {{end}}{{.ParamName}}{{end -}} }
+	}
+
+	list := []*{{$model.GoName}}{}
+	q := keyset(db.NewSelect().Model(&list), []string{ {{- range $i, $e := .Columns}}{{if $i}}, {{end}}Columns.{{$model.GoName}}.{{.GoName}}{{end -}} }, values, direction, limit)
+	for _, relation := range relations {
+		q = q.Relation(relation)
+	}{{if $dbstruct.WithSearch}}
+	if search != nil {
+		q = q.ApplyQueryBuilder(search.Apply)
+	}{{template "aliveElse" $model}}{{else}}{{template "aliveQuery" $model}}{{end}}
+
+	if err := q.Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	more := limit > 0 && len(list) > limit
+	if more {
+		list = list[:limit]
+	}
+	if direction == PagePrev {
+		for i, j := 0, len(list)-1; i < j; i, j = i+1, j-1 {
+			list[i], list[j] = list[j], list[i]
+		}
+	}
+
+	page := &{{$model.GoName}}Page{Items: list}
+	if len(list) == 0 {
+		return page, nil
+	}
+
+	var err error
+	first, last := list[0], list[len(list)-1]
+	if (direction == PageNext && more) || (direction == PagePrev && cursor != "") {
+		if page.Next, err = newCursor({{range $i, $e := .Columns}}{{if $i}}, {{end}}last.{{.GoName}}{{end}}); err != nil {
+			return nil, err
+		}
+	}
+	if (direction == PagePrev && more) || (direction == PageNext && cursor != "") {
+		if page.Prev, err = newCursor({{range $i, $e := .Columns}}{{if $i}}, {{end}}first.{{.GoName}}{{end}}); err != nil {
+			return nil, err
+		}
+	}
+
+	return page, nil
+}
+{{end}}{{end}}
+// Count counts {{.GoName}}{{if $dbstruct.WithSearch}} filtered by search{{end}}
+func ({{.GoName}}Repo) Count(ctx context.Context, db bun.IDB{{if $dbstruct.WithSearch}}, search *{{.GoName}}Search{{end}}) (int, error) {
+	q := db.NewSelect().Model((*{{.GoName}})(nil)){{if $dbstruct.WithSearch}}
+	if search != nil {
+		q = q.ApplyQueryBuilder(search.Apply)
+	}{{template "aliveElse" $model}}{{else}}{{template "alive" $model}}{{end}}
+
+	return q.Count(ctx)
+}
+
+// Exists checks if any {{.GoName}}{{if $dbstruct.WithSearch}} matching search{{end}} exists
+func ({{.GoName}}Repo) Exists(ctx context.Context, db bun.IDB{{if $dbstruct.WithSearch}}, search *{{.GoName}}Search{{end}}) (bool, error) {
+	q := db.NewSelect().Model((*{{.GoName}})(nil)){{if $dbstruct.WithSearch}}
+	if search != nil {
+		q = q.ApplyQueryBuilder(search.Apply)
+	}{{template "aliveElse" $model}}{{else}}{{template "alive" $model}}{{end}}
+
+	return q.Exists(ctx)
+}
+
+// Insert inserts {{.GoName}}, generated values are set to model
+func ({{.GoName}}Repo) Insert(ctx context.Context, db bun.IDB, m *{{.GoName}}) error {
+	_, err := db.NewInsert().Model(m).Exec(ctx)
+	return err
+}
+
+// InsertMany inserts list of {{.GoName}} in one query
+func ({{.GoName}}Repo) InsertMany(ctx context.Context, db bun.IDB, list []*{{.GoName}}) error {
+	if len(list) == 0 {
+		return nil
+	}
+
+	_, err := db.NewInsert().Model(&list).Exec(ctx)
+	return err
+}
+{{if .Upserts}}
+// UpsertColumns gets columns updated by upserts by default: all except primary key, generated and having default
+func ({{.GoName}}Repo) UpsertColumns() []string {
+	return []string{ {{- range $i, $e := .UpsertColumns}}{{if $i}}, {{end}}Columns.{{$model.GoName}}.{{.GoName}}{{end -}} }
+}
+{{range .Upserts}}
+// Upsert{{.Name}} inserts {{$model.GoName}} or updates it on {{.Index}} conflict
+// only given columns are updated if set, UpsertColumns are updated otherwise
+func ({{$model.GoName}}Repo) Upsert{{.Name}}(ctx context.Context, db bun.IDB, m *{{$model.GoName}}, columns ...string) error {
+	if len(columns) == 0 {
+		columns = {{$model.GoName}}Repo{}.UpsertColumns()
+	}
+
+	_, err := upsert(db.NewInsert().Model(m), []string{ {{- range $i, $e := .Columns}}{{if $i}}, {{end}}Columns.{{$model.GoName}}.{{.GoName}}{{end -}} }, columns).Exec(ctx)
+	return err
+}
+
+// UpsertMany{{.Name}} inserts or updates list of {{$model.GoName}} on {{.Index}} conflict in one query
+// only given columns are updated if set, UpsertColumns are updated otherwise
+func ({{$model.GoName}}Repo) UpsertMany{{.Name}}(ctx context.Context, db bun.IDB, list []*{{$model.GoName}}, columns ...string) error {
+	if len(list) == 0 {
+		return nil
+	}
+	if len(columns) == 0 {
+		columns = {{$model.GoName}}Repo{}.UpsertColumns()
+	}
+
+	_, err := upsert(db.NewInsert().Model(&list), []string{ {{- range $i, $e := .Columns}}{{if $i}}, {{end}}Columns.{{$model.GoName}}.{{.GoName}}{{end -}} }, columns).Exec(ctx)
+	return err
+}
+{{end}}{{end}}{{if .PKs}}
+// Update updates {{.GoName}} by primary key, only given columns are updated if set{{with .Hook}}{{if .HasUpdate}}, columns filled by hook are always updated{{end}}{{end}}
+// returns {{template "notFound" .}} if nothing updated
+func ({{.GoName}}Repo) Update(ctx context.Context, db bun.IDB, m *{{.GoName}}, columns ...string) error {
+	q := db.NewUpdate().Model(m).WherePK()
+	if len(columns) > 0 {
+		q = q.Column(columns...){{with .Version}}.Column(Columns.{{$model.GoName}}.{{.GoName}}){{end}}{{with .Hook}}{{with .UpdateColumns}}.Column({{range $i, $e := .}}{{if $i}}, {{end}}Columns.{{$model.GoName}}.{{.GoName}}{{end}}){{end}}{{end}}
+	}
+{{template "update" .}}
+}
+
+// UpdateMany updates list of {{.GoName}} by primary keys in one query{{if .Version}}
+// returns ErrStaleObject if any of them was changed or deleted, run it in transaction to roll back the others{{end}}
+func ({{.GoName}}Repo) UpdateMany(ctx context.Context, db bun.IDB, list []*{{.GoName}}) error {
+	if len(list) == 0 {
+		return nil
+	}
+{{with .Version}}
+	versions := make([]{{.Type}}, len(list))
+	for i, m := range list {
+		versions[i] = m.{{.GoName}}
+		m.{{.GoName}}++
+	}
+
+	res, err := db.NewUpdate().Model(&list).Bulk().
+		Where("?TableAlias.? = _data.? - 1", bun.Ident(Columns.{{$model.GoName}}.{{.GoName}}), bun.Ident(Columns.{{$model.GoName}}.{{.GoName}})).Exec(ctx){{template "updateMany" $model}}{{else}}
+	_, err := db.NewUpdate().Model(&list).Bulk().Exec(ctx)
+	return err{{end}}
+}
+
+// Diff gets columns of {{.GoName}} changed between old and new, primary key{{if .Version}}, version{{end}}{{if .SoftDelete}}, soft delete{{end}}{{if .Hook}}, hook filled{{end}} and generated columns are not compared
+func ({{.GoName}}Repo) Diff(old, new *{{.GoName}}) []string {
+	var columns []string{{range .PatchColumns}}
+	if changed(old.{{.GoName}}, new.{{.GoName}}) {
+		columns = append(columns, Columns.{{$model.GoName}}.{{.GoName}})
+	}{{end}}
+
+	return columns
+}
+
+// UpdateChanged updates only columns of {{.GoName}} changed between old and new by primary key of new
+// nothing is updated if there are no changes, returns {{template "notFound" .}} if nothing updated
+func ({{.GoName}}Repo) UpdateChanged(ctx context.Context, db bun.IDB, old, new *{{.GoName}}) error {
+	columns := {{.GoName}}Repo{}.Diff(old, new)
+	if len(columns) == 0 {
+		return nil
+	}
+
+	return {{.GoName}}Repo{}.Update(ctx, db, new, columns...)
+}
+
+// {{.GoName}}Patch is a partial update of {{.GoName}}, only not nil and set Optional fields are set
+type {{.GoName}}Patch struct { {{- range .PatchColumns}}
+	{{.GoName}} {{.PatchType}}{{if .PatchTag}} {{.PatchTag}}{{end}}{{end}}
+}
+
+// Apply sets not nil and set Optional fields of patch to m, returns columns set
+func (p *{{.GoName}}Patch) Apply(m *{{.GoName}}) []string {
+	var columns []string{{range .PatchColumns}}{{if .Optional}}
+	if p.{{.GoName}}.Set {
+		m.{{.GoName}} = p.{{.GoName}}.Value{{else}}
+	if p.{{.GoName}} != nil {
+		m.{{.GoName}} = {{if .Deref}}*{{end}}p.{{.GoName}}{{end}}
+		columns = append(columns, Columns.{{$model.GoName}}.{{.GoName}})
+	}{{end}}
+
+	return columns
+}
+
+// Patch sets not nil and set Optional fields of patch to m and updates only these columns by primary key
+// nothing is updated if patch is empty, returns {{template "notFound" .}} if nothing updated
+func ({{.GoName}}Repo) Patch(ctx context.Context, db bun.IDB, m *{{.GoName}}, patch *{{.GoName}}Patch) error {
+	columns := patch.Apply(m)
+	if len(columns) == 0 {
+		return nil
+	}
+
+	return {{.GoName}}Repo{}.Update(ctx, db, m, columns...)
+}
+
+// Delete {{if .SoftDelete}}marks {{.GoName}} deleted{{else}}deletes {{.GoName}}{{end}} by primary key, returns {{template "notFound" .}} if nothing deleted
+func ({{.GoName}}Repo) Delete(ctx context.Context, db bun.IDB, m *{{.GoName}}) error { {{- with .SoftDelete}}{{if .Update}}{{if .IsTime}}
+	now := time.Now(){{end}}
+	m.{{.Column.GoName}} = {{.Deleted}}
+	q := db.NewUpdate().Model(m).Column(Columns.{{$model.GoName}}.{{.Column.GoName}}{{with $model.Version}}, Columns.{{$model.GoName}}.{{.GoName}}{{end}}{{template "touched" $model}}).WherePK(){{template "alive" $model}}
+{{template "update" $model}}{{else}}
+	q := db.NewDelete().Model(m).WherePK()
+{{template "delete" $model}}{{end}}{{else}}
+	q := db.NewDelete().Model(m).WherePK()
+{{template "delete" .}}{{end}}
+}
+
+// DeleteMany {{if .SoftDelete}}marks list of {{.GoName}} deleted{{else}}deletes list of {{.GoName}}{{end}} by primary keys{{if .Version}}
+// returns ErrStaleObject if any of them was changed or deleted, run it in transaction to roll back the others{{end}}
+func ({{.GoName}}Repo) DeleteMany(ctx context.Context, db bun.IDB, list []*{{.GoName}}) error {
+	if len(list) == 0 {
+		return nil
+	}
+{{with .SoftDelete}}{{if .Update}}{{if .IsTime}}
+	now := time.Now(){{end}}
+	deleted := {{.Deleted}}{{with $model.Version}}
+	versions := make([]{{.Type}}, len(list))
+	for i, m := range list {
+		m.{{$model.SoftDelete.Column.GoName}} = deleted
+		versions[i] = m.{{.GoName}}
+		m.{{.GoName}}++
+	}
+
+	res, err := db.NewUpdate().Model(&list).Column(Columns.{{$model.GoName}}.{{$model.SoftDelete.Column.GoName}}, Columns.{{$model.GoName}}.{{.GoName}}{{template "touched" $model}}).Bulk().
+		Where("?TableAlias.? = _data.? - 1", bun.Ident(Columns.{{$model.GoName}}.{{.GoName}}), bun.Ident(Columns.{{$model.GoName}}.{{.GoName}})).Exec(ctx){{template "updateMany" $model}}{{else}}
+	for _, m := range list {
+		m.{{.Column.GoName}} = deleted
+	}
+
+	_, err := db.NewUpdate().Model(&list).Column(Columns.{{$model.GoName}}.{{.Column.GoName}}{{template "touched" $model}}).Bulk().Exec(ctx)
+	return err{{end}}{{else}}{{template "deleteMany" $model}}{{end}}{{else}}{{template "deleteMany" $model}}{{end}}
+}
+{{with .SoftDelete}}
+// Restore restores deleted {{$model.GoName}} by primary key, returns {{template "notFound" $model}} if it's not found or not deleted
+func ({{$model.GoName}}Repo) Restore(ctx context.Context, db bun.IDB, m *{{$model.GoName}}) error {
+	m.{{.Column.GoName}} = {{.Restored}}
+	q := db.NewUpdate().Model(m).Column(Columns.{{$model.GoName}}.{{.Column.GoName}}{{with $model.Version}}, Columns.{{$model.GoName}}.{{.GoName}}{{end}}{{template "touched" $model}}).WherePK(){{template "deleted" $model}}
+{{template "update" $model}}
+}
+
+// ForceDelete deletes {{$model.GoName}} from table by primary key, even if it is already marked deleted
+// returns {{template "notFound" $model}} if nothing deleted
+func ({{$model.GoName}}Repo) ForceDelete(ctx context.Context, db bun.IDB, m *{{$model.GoName}}) error {
+	q := db.NewDelete().Model(m).WherePK(){{if .IsTime}}.ForceDelete(){{end}}
+{{template "delete" $model}}
+}
+{{end}}{{end}}
+// Select{{.GoName}} gets all {{.GoName}}, use {{.GoName}}Repo for context, filters and pagination
 func (dbConn *{{ $dbstruct.ORMDbStruct }}) Select{{ .GoName }}() ([]*{{ .GoName }}, error) {
-	ctx := context.Background()
-	model := []*{{ .GoName }}{}
-	{{$parent := .}}
-	err := dbConn.NewSelect().
-		{{- range .Columns}}
-		{{- if $parent.NoAlias }}
-		Column("{{ .Column.PGName -}}").
-		{{- else}}
-		Column("{{ $parent.Alias -}}.{{ .Column.PGName -}}").
-		{{- end}}
-		{{- end}}
-		Model(&model).
-		Scan(ctx)
-	return model, err
+	return {{.GoName}}Repo{}.List(context.Background(), dbConn.IDB, {{if $dbstruct.WithSearch}}nil, {{end}}Pager{})
 }
 {{end}}
 {{- end}}
 
 {{- if .WithSearch}}
 /* Search Queries */
+` + searchModels + `
+{{- end}}
+{{- if .WithValidation}}
+` + validationModels + `
+{{- end}}
+{{- if .WithCopy}}
+/* COPY queries */
+{{- range $model := .Entities}}
 
+// Copy{{.GoNamePlural}} inserts rows into {{.PGFullName}} with COPY FROM STDIN in batches of CopyBatchSize rows, returns number of rows inserted
+// columns with database defaults are left out of batch if they are zero in all its rows
+// hooks are not called and values generated by database are not scanned back into rows
+func Copy{{.GoNamePlural}}(ctx context.Context, conn bun.Conn, rows []*{{.GoName}}) (int64, error) {
+	return copyFrom(ctx, conn, {{.GoName}}T.Table.Name(), len(rows), []copyColumn{ {{- range .CopyColumns}}
+		{name: Columns.{{$model.GoName}}.{{.GoName}}, kind: {{.Kind}}, {{if .Defaulted}}defaulted: true, {{end}}value: func(i int) interface{} { return rows[i].{{.GoName}} }},{{end}}
+	})
+}
+
+// CopyOut{{.GoNamePlural}} writes all rows of {{.PGFullName}} to w with COPY TO STDOUT in text format, returns number of rows written
+// columns are written in order used by Copy{{.GoNamePlural}}, output can be loaded back with pgdriver.CopyFrom
+func CopyOut{{.GoNamePlural}}(ctx context.Context, conn bun.Conn, w io.Writer) (int64, error) {
+	return copyTo(ctx, conn, w, {{.GoName}}T.Table.Name(), []string{ {{- range $i, $e := .CopyColumns}}{{if $i}}, {{end}}Columns.{{$model.GoName}}.{{.GoName}}{{end -}} })
+}
+{{- end}}
+{{- end}}
+{{define "pkField"}}{{if .Embed}}{{.Embed}}: {{.Embed}}{ {{- .GoName}}: pk}{{else}}{{.GoName}}: pk{{end}}{{end}}
+{{define "alive"}}{{with .SoftDelete}}{{if not .IsTime}}.
+		Where("?TableAlias.? = ?", bun.Ident(Columns.{{$.GoName}}.{{.Column.GoName}}), {{.Alive}}){{end}}{{end}}{{end}}
+{{define "deleted"}}{{with .SoftDelete}}{{if .IsTime}}.WhereDeleted(){{else}}.
+		Where("?TableAlias.? != ?", bun.Ident(Columns.{{$.GoName}}.{{.Column.GoName}}), {{.Alive}}){{end}}{{end}}{{end}}
+{{define "touched"}}{{with .Hook}}{{range .UpdateColumns}}, Columns.{{$.GoName}}.{{.GoName}}{{end}}{{end}}{{end}}
+{{define "aliveQuery"}}{{with .SoftDelete}}{{if not .IsTime}}
+	q = q{{template "alive" $}}{{end}}{{end}}{{end}}
+{{define "notFound"}}{{if .Version}}ErrStaleObject{{else}}sql.ErrNoRows{{end}}{{end}}
+{{define "update"}}{{with .Version}}
+	version := m.{{.GoName}}
+	m.{{.GoName}}++
+	res, err := q.Where("?TableAlias.? = ?", bun.Ident(Columns.{{$.GoName}}.{{.GoName}}), version).Exec(ctx)
+	if err = stale(res, err, 1); err != nil {
+		m.{{.GoName}} = version
+	}
+
+	return err{{else}}
+	return affected(q.Exec(ctx)){{end}}{{end}}
+{{define "updateMany"}}{{with .Version}}
+	if err = stale(res, err, len(list)); err != nil {
+		for i, m := range list {
+			m.{{.GoName}} = versions[i]
+		}
+	}
+
+	return err{{end}}{{end}}
+{{define "delete"}}{{with .Version}}
+	res, err := q.Where("?TableAlias.? = ?", bun.Ident(Columns.{{$.GoName}}.{{.GoName}}), m.{{.GoName}}).Exec(ctx)
+	return stale(res, err, 1){{else}}
+	return affected(q.Exec(ctx)){{end}}{{end}}
+{{define "deleteMany"}}{{with .Version}}
+	values := make([][]interface{}, len(list))
+	for i, m := range list {
+		values[i] = []interface{}{ {{- range $.PKs}}m.{{.GoName}}, {{end}}m.{{.GoName -}} }
+	}
+
+	res, err := db.NewDelete().Model(&list).
+		Where("({{range $.PKs}}?TableAlias.?, {{end}}?TableAlias.?) IN (?)", {{range $.PKs}}bun.Ident(Columns.{{$.GoName}}.{{.GoName}}), {{end}}bun.Ident(Columns.{{$.GoName}}.{{.GoName}}), bun.In(values)).Exec(ctx)
+	return stale(res, err, len(list)){{else}}
+	_, err := db.NewDelete().Model(&list).WherePK().Exec(ctx)
+	return err{{end}}{{end}}
+{{define "aliveElse"}}{{with .SoftDelete}}{{if not .IsTime}} else {
+		q = q{{template "alive" $}}
+	}{{end}}{{end}}{{end}}`
+
+// searchModels are search structs of entities
+const searchModels = `
 {{range $model := .Entities}}
 type {{.GoName}}Search struct {
 	search
 
 	{{range .Columns}}
 	{{.GoName}} {{.SearchType}}{{if .HasTags}} {{.Tag}}{{end}}{{end}}
+	{{range .Filters}}
+	{{.GoName}} {{.Type}}{{end}}
+
+	// {{.SortField}} orders rows, see Parse{{.GoName}}Sort
+	{{.SortField}} []{{.GoName}}Sort
+	// {{.FieldsField}} selects only given columns, primary and keyset columns are always selected, see Parse{{.GoName}}Fields
+	{{.FieldsField}} []string{{with .SoftDelete}}
+
+	// {{.WithDeletedField}} includes rows marked deleted, {{.OnlyDeletedField}} selects only them
+	{{.WithDeletedField}} bool
+	{{.OnlyDeletedField}} bool{{end}}
 }
 
 func (s *{{.GoName}}Search) Apply(query bun.QueryBuilder) bun.QueryBuilder { {{range .Columns}}{{if .Relaxed}}
 	if !reflect.ValueOf(s.{{.GoName}}).IsNil(){ {{else}}
 	if s.{{.GoName}} != nil { {{end}}{{if .UseCustomRender}}
 		{{.CustomRender}}{{else}}
-		s.where(query, {{$model.GoName}}T.Table.Name(), Columns.{{$model.GoName}}.{{.GoName}}, s.{{.GoName}}){{end}}
+		s.where(query, {{$model.GoName}}T.Table.Ref(), Columns.{{$model.GoName}}.{{.GoName}}, s.{{.GoName}}){{end}}
 	}{{end}}
+{{range .Filters}}
+	if s.{{.GoName}} != nil {
+		{{.Render}}
+	}{{end}}{{with .SoftDelete}}{{if .IsTime}}
+	if s.{{.OnlyDeletedField}} {
+		query.WhereDeleted()
+	} else if s.{{.WithDeletedField}} {
+		query.WhereAllWithDeleted()
+	}{{else}}
+	if s.{{.OnlyDeletedField}} {
+		s.op(query, {{$model.GoName}}T.Table.Ref(), Columns.{{$model.GoName}}.{{.Column.GoName}}, "!=", {{.Alive}})
+	} else if !s.{{.WithDeletedField}} {
+		s.where(query, {{$model.GoName}}T.Table.Ref(), Columns.{{$model.GoName}}.{{.Column.GoName}}, {{.Alive}})
+	}{{end}}{{end}}
+	for _, sort := range s.{{.SortField}} {
+		s.order(query, {{.GoName}}T.Table.Ref(), string(sort.Field), sort.Desc, sort.Nulls)
+	}
+	s.columns(query, {{.GoName}}T.Table.Ref(), s.{{.FieldsField}}{{range .KeyColumns}}, Columns.{{$model.GoName}}.{{.GoName}}{{end}})
 
 	s.apply(query)
 
 	return query
 }
 
+// {{.GoName}}SortField is a column {{.GoName}} can be sorted by
+type {{.GoName}}SortField string
+
+const ({{range .SortColumns}}
+	{{$model.GoName}}SortBy{{.GoName}} {{$model.GoName}}SortField = "{{.PGName}}"{{end}}
+)
+
+// {{.GoName}}Sort is a sort specification of {{.GoName}}
+type {{.GoName}}Sort struct {
+	Field {{.GoName}}SortField
+	Desc  bool
+	Nulls NullsOrder
+}
+
+// Parse{{.GoName}}Sort parses comma separated list of columns{{with .SortColumns}}, e.g. "-{{(index . 0).PGName}}"{{end}}
+// minus sign means descending order, ":nulls_first" or ":nulls_last" suffix sets nulls order
+func Parse{{.GoName}}Sort(value string) ([]{{.GoName}}Sort, error) {
+	var result []{{.GoName}}Sort
+	for _, item := range strings.Split(value, ",") {
+		column, desc, nulls, err := parseSortItem(item)
+		if err != nil {
+			return nil, err
+		}
+		if column == "" {
+			continue
+		}
+
+		switch field := {{.GoName}}SortField(column); field { {{- if .SortColumns}}
+		case {{range $i, $e := .SortColumns}}{{if $i}}, {{end}}{{$model.GoName}}SortBy{{.GoName}}{{end}}:
+			result = append(result, {{.GoName}}Sort{Field: field, Desc: desc, Nulls: nulls}){{end}}
+		default:
+			return nil, fmt.Errorf("%w %q for sorting {{.GoName}}", ErrUnknownColumn, column)
+		}
+	}
+
+	return result, nil
+}
+
+// Parse{{.GoName}}Fields parses comma separated list of columns for projection
+func Parse{{.GoName}}Fields(value string) ([]string, error) {
+	var result []string
+	for _, item := range strings.Split(value, ",") {
+		column := strings.TrimSpace(item)
+		if column == "" {
+			continue
+		}
+
+		switch column { {{- if .FieldColumns}}
+		case {{range $i, $e := .FieldColumns}}{{if $i}}, {{end}}Columns.{{$model.GoName}}.{{.GoName}}{{end}}:
+			result = append(result, column){{end}}
+		default:
+			return nil, fmt.Errorf("%w %q for {{.GoName}} fields", ErrUnknownColumn, column)
+		}
+	}
+
+	return result, nil
+}
+
+// Parse{{.GoName}}Search builds {{.GoName}}Search from query parameters named after columns with optional operator{{with .Params}}, e.g. {{(index . 0).Name}}{{end}}
+// list operators accept comma separated values, all invalid parameters are returned as SearchErrors
+func Parse{{.GoName}}Search(values url.Values) (*{{.GoName}}Search, error) {
+	search := &{{.GoName}}Search{}
+	err := parseSearch(values, []string{ {{- range $i, $e := .FieldColumns}}{{if $i}}, {{end}}Columns.{{$model.GoName}}.{{.GoName}}{{end -}} }, map[string]interface{}{ {{- range .Params}}
+		"{{.Name}}": {{.Target}},{{end}}
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return search, nil
+}
+
 func (s *{{.GoName}}Search) Q() applier {
 	return func(query bun.QueryBuilder) (bun.QueryBuilder, error) {
 		return s.Apply(query), nil
 	}
 }
 {{end}}
-{{- end}}
+`
+
+// validationModels are Validate methods of entities
+const validationModels = `{{range $model := .Entities}}
+// Validate checks {{.GoName}} against columns metadata, returns ValidationErrors with all failed rules
+func (m *{{.GoName}}) Validate() error {
+	var errs ValidationErrors
+{{range .Checks}}
+	if {{.Cond}} {
+		errs = append(errs, FieldError{Field: Columns.{{$model.GoName}}.{{.Column.GoName}}, Code: {{.Code}}, Message: {{.Message}}})
+	}{{end}}{{range .Unchecked}}
+	// {{.Name}} is not validated: {{.Definition}}{{end}}
+
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+{{end}}
 `
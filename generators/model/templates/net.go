@@ -0,0 +1,159 @@
+package templates
+
+const Net = `//nolint
+//lint:file-ignore U1000 ignore unused code, it's generated
+package {{.Package}}
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"net"
+	"net/netip"
+	"strings"
+)
+
+// Inet is a postgres inet host address based on netip.Addr
+// Bits is a netmask length of address, 0 for host address without netmask
+type Inet struct {
+	netip.Addr
+	Bits int
+}
+
+// Scan implements sql.Scanner
+func (a *Inet) Scan(src interface{}) error {
+	s, err := netString(src)
+	if err != nil || s == "" {
+		a.Addr, a.Bits = netip.Addr{}, 0
+		return err
+	}
+
+	if strings.Contains(s, "/") {
+		prefix, err := netip.ParsePrefix(s)
+		if err != nil {
+			return err
+		}
+		a.Addr, a.Bits = prefix.Addr(), prefix.Bits()
+		// postgres omits netmask of host address
+		if a.Bits == a.Addr.BitLen() {
+			a.Bits = 0
+		}
+		return nil
+	}
+
+	a.Bits = 0
+	a.Addr, err = netip.ParseAddr(s)
+	return err
+}
+
+// Value implements driver.Valuer
+func (a Inet) Value() (driver.Value, error) {
+	if !a.IsValid() {
+		return nil, nil
+	}
+	if a.Bits != 0 {
+		return netip.PrefixFrom(a.Addr, a.Bits).String(), nil
+	}
+	return a.Addr.String(), nil
+}
+
+// Validate checks if address is set and valid and netmask fits it
+func (a Inet) Validate() error {
+	if !a.IsValid() {
+		return fmt.Errorf("invalid inet address")
+	}
+	if a.Bits < 0 || a.Bits > a.BitLen() {
+		return fmt.Errorf("invalid inet netmask length %d", a.Bits)
+	}
+	return nil
+}
+
+// Cidr is a postgres cidr network based on netip.Prefix
+type Cidr struct {
+	netip.Prefix
+}
+
+// Scan implements sql.Scanner
+func (p *Cidr) Scan(src interface{}) error {
+	s, err := netString(src)
+	if err != nil || s == "" {
+		p.Prefix = netip.Prefix{}
+		return err
+	}
+
+	if !strings.Contains(s, "/") {
+		addr, err := netip.ParseAddr(s)
+		if err != nil {
+			return err
+		}
+		p.Prefix = netip.PrefixFrom(addr, addr.BitLen())
+		return nil
+	}
+
+	p.Prefix, err = netip.ParsePrefix(s)
+	return err
+}
+
+// Value implements driver.Valuer
+func (p Cidr) Value() (driver.Value, error) {
+	if !p.IsValid() {
+		return nil, nil
+	}
+	return p.String(), nil
+}
+
+// Validate checks if network is set, valid and has no bits set to the right of netmask
+func (p Cidr) Validate() error {
+	if !p.IsValid() {
+		return fmt.Errorf("invalid cidr network")
+	}
+	if p.Prefix != p.Masked() {
+		return fmt.Errorf("cidr network %s has bits set to right of mask", p)
+	}
+	return nil
+}
+
+// MacAddr is a postgres macaddr or macaddr8 based on net.HardwareAddr
+type MacAddr struct {
+	net.HardwareAddr
+}
+
+// Scan implements sql.Scanner
+func (m *MacAddr) Scan(src interface{}) error {
+	s, err := netString(src)
+	if err != nil || s == "" {
+		m.HardwareAddr = nil
+		return err
+	}
+
+	m.HardwareAddr, err = net.ParseMAC(s)
+	return err
+}
+
+// Value implements driver.Valuer
+func (m MacAddr) Value() (driver.Value, error) {
+	if len(m.HardwareAddr) == 0 {
+		return nil, nil
+	}
+	return m.String(), nil
+}
+
+// Validate checks if address is set and has valid length (6 bytes for macaddr or 8 bytes for macaddr8)
+func (m MacAddr) Validate() error {
+	if l := len(m.HardwareAddr); l != 6 && l != 8 {
+		return fmt.Errorf("invalid mac address length %d", l)
+	}
+	return nil
+}
+
+func netString(src interface{}) (string, error) {
+	switch v := src.(type) {
+	case nil:
+		return "", nil
+	case string:
+		return v, nil
+	case []byte:
+		return string(v), nil
+	}
+	return "", fmt.Errorf("unsupported network address source type %T", src)
+}
+`
@@ -5,6 +5,18 @@ const Search = `//nolint
 package {{.Package}}
 
 import (
+	"database/sql"
+	"encoding"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
 	"github.com/uptrace/bun"
 )
 
@@ -29,6 +41,46 @@ func (s *search) where(query bun.QueryBuilder, table, field string, value interf
 
 }
 
+// op adds condition with operator for qualified column, e.g. "t"."count" >= 10
+func (s *search) op(query bun.QueryBuilder, table, field, op string, value interface{}) {
+	query.Where("?.? ? ?", bun.Ident(table), bun.Ident(field), bun.Safe(op), value)
+}
+
+// in adds IN or NOT IN condition, empty list matches nothing for IN and everything for NOT IN
+func (s *search) in(query bun.QueryBuilder, table, field string, values interface{}, not bool) {
+	if v := reflect.ValueOf(values); v.Kind() == reflect.Slice && v.Len() == 0 {
+		if !not {
+			query.Where("FALSE")
+		}
+		return
+	}
+
+	op := "IN"
+	if not {
+		op = "NOT IN"
+	}
+	query.Where("?.? ? (?)", bun.Ident(table), bun.Ident(field), bun.Safe(op), bun.In(values))
+}
+
+// null adds IS NULL or IS NOT NULL condition
+func (s *search) null(query bun.QueryBuilder, table, field string, isNull bool) {
+	op := "IS NOT NULL"
+	if isNull {
+		op = "IS NULL"
+	}
+	query.Where("?.? ?", bun.Ident(table), bun.Ident(field), bun.Safe(op))
+}
+
+// hasKey adds jsonb key existence condition
+func (s *search) hasKey(query bun.QueryBuilder, table, field, key string) {
+	query.Where("?.? \\? ?", bun.Ident(table), bun.Ident(field), key)
+}
+
+// likePrefix makes LIKE pattern matching strings starting with value
+func likePrefix(value string) string {
+	return strings.NewReplacer("\\", "\\\\", "%", "\\%", "_", "\\_").Replace(value) + "%"
+}
+
 func (s *search) WithApply(a applier) {
 	if s.appliers == nil {
 		s.appliers = []applier{}
@@ -42,6 +94,281 @@ func (s *search) With(condition string, params ...interface{}) {
 	})
 }
 
+// WithOr adds group of searchers joined by OR, conditions of every searcher are joined by AND
+func (s *search) WithOr(searchers ...Searcher) {
+	s.WithApply(func(query bun.QueryBuilder) (bun.QueryBuilder, error) {
+		return query.WhereGroup(" AND ", func(query bun.QueryBuilder) bun.QueryBuilder {
+			for _, searcher := range searchers {
+				query = query.WhereGroup(" OR ", searcher.Apply)
+			}
+			return query
+		}), nil
+	})
+}
+
+// ErrUnknownColumn is returned by sort and fields parsers for unknown or unsupported column
+var ErrUnknownColumn = errors.New("unknown column")
+
+// NullsOrder sets position of NULL values in sorting
+type NullsOrder int
+
+const (
+	// NullsDefault keeps database default: NULLS LAST for ascending order, NULLS FIRST for descending
+	NullsDefault NullsOrder = iota
+	NullsFirst
+	NullsLast
+)
+
+// parseSortItem parses sort item like "-name:nulls_last", minus sign means descending order
+func parseSortItem(item string) (column string, desc bool, nulls NullsOrder, err error) {
+	column = strings.TrimSpace(item)
+	if i := strings.LastIndexByte(column, ':'); i >= 0 {
+		switch column[i+1:] {
+		case "nulls_first":
+			nulls = NullsFirst
+		case "nulls_last":
+			nulls = NullsLast
+		default:
+			return "", false, NullsDefault, fmt.Errorf("unknown nulls order %q in %q", column[i+1:], item)
+		}
+		column = column[:i]
+	}
+
+	if strings.HasPrefix(column, "-") {
+		column, desc = column[1:], true
+	} else {
+		column = strings.TrimPrefix(column, "+")
+	}
+
+	return column, desc, nulls, nil
+}
+
+// order adds ORDER BY qualified column for select queries
+func (s *search) order(query bun.QueryBuilder, table, field string, desc bool, nulls NullsOrder) {
+	q, ok := query.Unwrap().(*bun.SelectQuery)
+	if !ok {
+		return
+	}
+
+	order := "ASC"
+	if desc {
+		order = "DESC"
+	}
+	switch nulls {
+	case NullsFirst:
+		order += " NULLS FIRST"
+	case NullsLast:
+		order += " NULLS LAST"
+	}
+
+	q.OrderExpr("?.? ?", bun.Ident(table), bun.Ident(field), bun.Safe(order))
+}
+
+// columns selects only given qualified columns for select queries, required columns are always selected
+func (s *search) columns(query bun.QueryBuilder, table string, fields []string, required ...string) {
+	q, ok := query.Unwrap().(*bun.SelectQuery)
+	if !ok || len(fields) == 0 {
+		return
+	}
+
+	selected := map[string]bool{}
+	for _, field := range append(required, fields...) {
+		if !selected[field] {
+			selected[field] = true
+			q.ColumnExpr("?.?", bun.Ident(table), bun.Ident(field))
+		}
+	}
+}
+
+var (
+	// ErrUnknownParam is returned by query parameters parsers for unknown operator or not allowed parameter
+	ErrUnknownParam = errors.New("unknown or not allowed parameter")
+	// ErrInvalidValue is returned by query parameters parsers if value can't be converted to field type
+	ErrInvalidValue = errors.New("invalid value")
+)
+
+// SearchError describes invalid query parameter
+type SearchError struct {
+	Param string
+	Value string
+	Err   error
+}
+
+func (e SearchError) Error() string {
+	return fmt.Sprintf("%s=%q: %v", e.Param, e.Value, e.Err)
+}
+
+func (e SearchError) Unwrap() error {
+	return e.Err
+}
+
+// SearchErrors is a list of all invalid query parameters
+type SearchErrors []SearchError
+
+func (e SearchErrors) Error() string {
+	messages := make([]string, len(e))
+	for i, err := range e {
+		messages[i] = err.Error()
+	}
+	return strings.Join(messages, "; ")
+}
+
+// parseSearch sets search fields from query parameters like "email", "email[eq]" or "createdAt[gte]"
+// params maps parameter name to pointer to field or function setting it
+// parameters not related to columns are skipped, so they can be used for pagination
+func parseSearch(values url.Values, columns []string, params map[string]interface{}) error {
+	known := map[string]bool{}
+	for _, column := range columns {
+		known[column] = true
+	}
+
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var errs SearchErrors
+	for _, key := range keys {
+		name, column, op := key, key, "eq"
+		if i := strings.IndexByte(key, '['); i > 0 && strings.HasSuffix(key, "]") {
+			column, op = key[:i], key[i+1:len(key)-1]
+			if op == "eq" {
+				name = column
+			}
+		}
+
+		target, ok := params[name]
+		if !ok {
+			if known[column] {
+				errs = append(errs, SearchError{Param: key, Value: strings.Join(values[key], ","), Err: ErrUnknownParam})
+			}
+			continue
+		}
+
+		if err := setParam(target, op, values[key]); err != nil {
+			errs = append(errs, SearchError{Param: key, Value: strings.Join(values[key], ","), Err: err})
+		}
+	}
+
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+// setParam sets field or calls function with parameter values
+// list operators accept comma separated and repeated values
+func setParam(target interface{}, op string, values []string) error {
+	list := op == "in" || op == "not_in" || op == "contains" || op == "overlaps"
+
+	var items []string
+	for _, value := range values {
+		if list {
+			items = append(items, strings.Split(value, ",")...)
+		} else {
+			items = append(items, value)
+		}
+	}
+	if len(items) == 0 {
+		return fmt.Errorf("%w: no value", ErrInvalidValue)
+	}
+	if !list && len(items) > 1 {
+		return fmt.Errorf("%w: multiple values", ErrInvalidValue)
+	}
+
+	if fn, ok := target.(func(string) error); ok {
+		return fn(items[0])
+	}
+
+	field := reflect.ValueOf(target).Elem()
+	switch {
+	case field.Kind() == reflect.Interface && list:
+		field.Set(reflect.ValueOf(items))
+	case field.Kind() == reflect.Interface:
+		field.Set(reflect.ValueOf(items[0]))
+	case field.Kind() == reflect.Slice:
+		slice := reflect.MakeSlice(field.Type(), len(items), len(items))
+		for i, item := range items {
+			if err := parseValue(slice.Index(i), item); err != nil {
+				return err
+			}
+		}
+		field.Set(slice)
+	case field.Kind() == reflect.Ptr:
+		value := reflect.New(field.Type().Elem())
+		if err := parseValue(value.Elem(), items[0]); err != nil {
+			return err
+		}
+		field.Set(value)
+	default:
+		return fmt.Errorf("%w: unsupported field type %s", ErrInvalidValue, field.Type())
+	}
+
+	return nil
+}
+
+// parseValue converts string to value of basic, time or text unmarshaler or sql scanner type
+func parseValue(value reflect.Value, raw string) error {
+	var err error
+	switch target := value.Addr().Interface().(type) {
+	case *time.Time:
+		if *target, err = time.Parse(time.RFC3339Nano, raw); err != nil {
+			if *target, err = time.Parse("2006-01-02", raw); err != nil {
+				err = errors.New("RFC 3339 time or date expected")
+			}
+		}
+	case encoding.TextUnmarshaler:
+		err = target.UnmarshalText([]byte(raw))
+	case sql.Scanner:
+		err = target.Scan(raw)
+	default:
+		switch value.Kind() {
+		case reflect.String:
+			value.SetString(raw)
+		case reflect.Bool:
+			var b bool
+			b, err = strconv.ParseBool(raw)
+			value.SetBool(b)
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			var n int64
+			n, err = strconv.ParseInt(raw, 10, value.Type().Bits())
+			value.SetInt(n)
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			var n uint64
+			n, err = strconv.ParseUint(raw, 10, value.Type().Bits())
+			value.SetUint(n)
+		case reflect.Float32, reflect.Float64:
+			var f float64
+			f, err = strconv.ParseFloat(raw, value.Type().Bits())
+			value.SetFloat(f)
+		case reflect.Slice:
+			if value.Type().Elem().Kind() != reflect.Uint8 {
+				return fmt.Errorf("%w: unsupported type %s", ErrInvalidValue, value.Type())
+			}
+			value.SetBytes([]byte(raw))
+		default:
+			return fmt.Errorf("%w: unsupported type %s", ErrInvalidValue, value.Type())
+		}
+	}
+
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidValue, err)
+	}
+	return nil
+}
+
+// jsonParam sets raw JSON value to field, value is validated
+func jsonParam(target *interface{}) func(string) error {
+	return func(value string) error {
+		if !json.Valid([]byte(value)) {
+			return fmt.Errorf("%w: malformed JSON", ErrInvalidValue)
+		}
+		*target = json.RawMessage(value)
+		return nil
+	}
+}
+
 // Searcher is interface for every generated filter
 type Searcher interface {
 	Apply(query bun.QueryBuilder) bun.QueryBuilder
@@ -49,5 +376,16 @@ type Searcher interface {
 
 	With(condition string, params ...interface{})
 	WithApply(a applier)
+	WithOr(searchers ...Searcher)
 }
 `
+
+// SearchModel is a file with search structs of entities, it's generated by search generator
+const SearchModel = `//nolint
+//lint:file-ignore U1000 ignore unused code, it's generated
+package {{.Package}}
+
+import ({{range .SearchImports}}
+	"{{.}}"{{end}}
+)
+` + searchModels
@@ -2,9 +2,12 @@ package templates
 
 const Tables = `//nolint
 //lint:file-ignore U1000 ignore unused code, it's generated
-package {{.Package}}{{if .HasImports}}
+package {{.Package}}
 
-{{end}}
+import (
+	"github.com/uptrace/bun"
+	"github.com/uptrace/bun/schema"
+)
 
 {{range .Entities}}
 	type Columns{{.GoName}} struct{
@@ -39,6 +42,58 @@ func (t TableInfo) Alias() string {
 	return t.alias
 }
 
+// Ref gets alias if set or name otherwise, used to qualify columns in queries
+func (t TableInfo) Ref() string {
+	if t.alias != "" {
+		return t.alias
+	}
+	return t.name
+}
+
+// Column is a typed column of table, it builds qualified expressions for queries
+// e.g. q.Where("?", UserColumns.Email.Eq(email)) or q.OrderExpr("? DESC", UserColumns.ID.Qualified())
+type Column struct {
+	table TableInfo
+	name  string
+}
+
+// Name gets column name
+func (c Column) Name() string {
+	return c.name
+}
+
+func (c Column) String() string {
+	return c.name
+}
+
+// Table gets table of column
+func (c Column) Table() TableInfo {
+	return c.table
+}
+
+// Ident gets column name as identifier, e.g. "email"
+func (c Column) Ident() bun.Ident {
+	return bun.Ident(c.name)
+}
+
+// Qualified gets column qualified with table alias or name, e.g. "t"."email"
+func (c Column) Qualified() schema.QueryWithArgs {
+	return schema.SafeQuery("?.?", []interface{}{bun.Ident(c.table.Ref()), bun.Ident(c.name)})
+}
+
+// Eq gets condition column = value
+func (c Column) Eq(value interface{}) schema.QueryWithArgs {
+	return schema.SafeQuery("?.? = ?", []interface{}{bun.Ident(c.table.Ref()), bun.Ident(c.name), value})
+}
+
+// In gets condition column IN (values), empty list matches nothing
+func (c Column) In(values ...interface{}) schema.QueryWithArgs {
+	if len(values) == 0 {
+		return schema.SafeQuery("FALSE", nil)
+	}
+	return schema.SafeQuery("?.? IN (?)", []interface{}{bun.Ident(c.table.Ref()), bun.Ident(c.name), bun.In(values)})
+}
+
 {{range .Entities}}
 type {{.GoName}}Table struct {
 	Columns{{.GoName}}
@@ -51,6 +106,16 @@ var {{.GoName}}T = {{.GoName}}Table {
 }
 {{end}}
 
+{{range .Entities}}
+type {{.GoName}}ColumnSet struct {
+	{{range $i, $e := .Columns}}{{if $i}}, {{end}}{{.GoName}}{{end}} Column
+}
+
+var {{.GoName}}Columns = {{.GoName}}ColumnSet{ {{- $model := .}}{{range .Columns}}
+	{{.GoName}}: Column{table: {{$model.GoName}}T.Table, name: "{{.PGName}}"},{{end}}
+}
+{{end}}
+
 type TablesSt struct { {{range .Entities}}
 		{{.GoName}} {{.GoName}}Table{{end}}
 }
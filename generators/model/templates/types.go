@@ -0,0 +1,242 @@
+package templates
+
+const Types = `//nolint
+//lint:file-ignore U1000 ignore unused code, it's generated
+package {{.Package}}
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"encoding/hex"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"{{range .CompositeImports}}
+	"{{.}}"{{end}}
+)
+{{range .Composites}}
+// {{.GoName}} is a postgres composite type {{.PGFullName}}
+type {{.GoName}} struct { {{range .Fields}}
+	{{.GoName}} {{.Type}}{{end}}
+}
+
+// Scan implements sql.Scanner, parses postgres row literal
+func (t *{{.GoName}}) Scan(src interface{}) error {
+	return scanComposite(src{{range .Fields}}, &t.{{.GoName}}{{end}})
+}
+
+// Value implements driver.Valuer, builds postgres row literal
+func (t {{.GoName}}) Value() (driver.Value, error) {
+	return compositeValue({{range $i, $e := .Fields}}{{if $i}}, {{end}}t.{{.GoName}}{{end}})
+}
+{{end}}
+// compositeTimeLayouts are layouts used by postgres to print dates and times
+var compositeTimeLayouts = []string{
+	"2006-01-02 15:04:05.999999999Z07:00:00",
+	"2006-01-02 15:04:05.999999999Z07:00",
+	"2006-01-02 15:04:05.999999999Z07",
+	"2006-01-02 15:04:05.999999999",
+	"2006-01-02",
+	"15:04:05.999999999Z07:00",
+	"15:04:05.999999999Z07",
+	"15:04:05.999999999",
+}
+
+// parseComposite splits postgres row literal into fields, nil means NULL
+func parseComposite(src interface{}) ([]*string, error) {
+	var s string
+	switch v := src.(type) {
+	case string:
+		s = v
+	case []byte:
+		s = string(v)
+	default:
+		return nil, fmt.Errorf("unsupported composite source type %T", src)
+	}
+
+	if len(s) < 2 || s[0] != '(' || s[len(s)-1] != ')' {
+		return nil, fmt.Errorf("invalid composite literal %q", s)
+	}
+	s = s[1 : len(s)-1]
+
+	var (
+		fields []*string
+		field  strings.Builder
+		quoted bool
+		null   = true
+	)
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case quoted && c == '"' && i+1 < len(s) && s[i+1] == '"':
+			field.WriteByte('"')
+			i++
+		case c == '"':
+			quoted = !quoted
+			null = false
+		case c == '\\' && i+1 < len(s):
+			field.WriteByte(s[i+1])
+			null = false
+			i++
+		case c == ',' && !quoted:
+			fields = append(fields, compositeField(field.String(), null))
+			field.Reset()
+			null = true
+		default:
+			field.WriteByte(c)
+			null = false
+		}
+	}
+
+	return append(fields, compositeField(field.String(), null)), nil
+}
+
+func compositeField(s string, null bool) *string {
+	if null {
+		return nil
+	}
+	return &s
+}
+
+// scanComposite scans row literal fields into destinations
+func scanComposite(src interface{}, dest ...interface{}) error {
+	fields, err := parseComposite(src)
+	if err != nil {
+		return err
+	}
+
+	if len(fields) != len(dest) {
+		return fmt.Errorf("composite has %d fields, want %d", len(fields), len(dest))
+	}
+
+	for i, field := range fields {
+		if err := scanCompositeField(field, reflect.ValueOf(dest[i]).Elem()); err != nil {
+			return fmt.Errorf("scan composite field %d: %w", i, err)
+		}
+	}
+
+	return nil
+}
+
+func scanCompositeField(field *string, v reflect.Value) error {
+	if field == nil {
+		v.Set(reflect.Zero(v.Type()))
+		return nil
+	}
+
+	if v.Kind() == reflect.Ptr {
+		v.Set(reflect.New(v.Type().Elem()))
+		v = v.Elem()
+	}
+
+	if scanner, ok := v.Addr().Interface().(sql.Scanner); ok {
+		return scanner.Scan(*field)
+	}
+
+	switch v.Interface().(type) {
+	case time.Time:
+		for _, layout := range compositeTimeLayouts {
+			if t, err := time.Parse(layout, *field); err == nil {
+				v.Set(reflect.ValueOf(t))
+				return nil
+			}
+		}
+		return fmt.Errorf("invalid time %q", *field)
+	case []byte:
+		b, err := hex.DecodeString(strings.TrimPrefix(*field, "\\x"))
+		if err != nil {
+			return err
+		}
+		v.SetBytes(b)
+		return nil
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(*field)
+	case reflect.Bool:
+		v.SetBool(*field == "t" || *field == "true")
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(*field, 10, 64)
+		if err != nil {
+			return err
+		}
+		v.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(*field, 10, 64)
+		if err != nil {
+			return err
+		}
+		v.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		n, err := strconv.ParseFloat(*field, 64)
+		if err != nil {
+			return err
+		}
+		v.SetFloat(n)
+	default:
+		return fmt.Errorf("unsupported type %s", v.Type())
+	}
+
+	return nil
+}
+
+// compositeValue builds postgres row literal from fields
+func compositeValue(fields ...interface{}) (driver.Value, error) {
+	parts := make([]string, len(fields))
+	for i, field := range fields {
+		s, ok, err := compositeFieldValue(field)
+		if err != nil {
+			return nil, fmt.Errorf("composite field %d: %w", i, err)
+		}
+		if ok {
+			parts[i] = "\"" + strings.NewReplacer("\\", "\\\\", "\"", "\\\"").Replace(s) + "\""
+		}
+	}
+
+	return "(" + strings.Join(parts, ",") + ")", nil
+}
+
+func compositeFieldValue(field interface{}) (string, bool, error) {
+	v := reflect.ValueOf(field)
+	if !v.IsValid() || (v.Kind() == reflect.Ptr && v.IsNil()) {
+		return "", false, nil
+	}
+	if valuer, ok := field.(driver.Valuer); ok {
+		value, err := valuer.Value()
+		if err != nil || value == nil {
+			return "", false, err
+		}
+		return compositeFieldValue(value)
+	}
+	if v.Kind() == reflect.Ptr {
+		return compositeFieldValue(v.Elem().Interface())
+	}
+
+	switch f := field.(type) {
+	case string:
+		return f, true, nil
+	case []byte:
+		return "\\x" + hex.EncodeToString(f), true, nil
+	case time.Time:
+		return f.Format("2006-01-02 15:04:05.999999999Z07:00"), true, nil
+	case bool:
+		if f {
+			return "t", true, nil
+		}
+		return "f", true, nil
+	}
+
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(v.Int(), 10), true, nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(v.Uint(), 10), true, nil
+	case reflect.Float32, reflect.Float64:
+		return strconv.FormatFloat(v.Float(), 'g', -1, 64), true, nil
+	}
+
+	return "", false, fmt.Errorf("unsupported type %T", field)
+}
+`
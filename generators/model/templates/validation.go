@@ -0,0 +1,137 @@
+package templates
+
+const Validation = `//nolint
+//lint:file-ignore U1000 ignore unused code, it's generated
+package {{.Package}}
+
+import (
+	"math"
+	"reflect"
+	"regexp"
+	"strings"
+	"sync"
+	"unicode/utf8"
+)
+
+// validation codes of FieldError
+const (
+	// ValidationRequired is set if NOT NULL column without default is empty
+	ValidationRequired = "required"
+	// ValidationMaxLength is set if value is longer than varchar(n) or char(n) column allows
+	ValidationMaxLength = "max_length"
+	// ValidationEnum is set if value is not a label of enum type
+	ValidationEnum = "enum"
+	// ValidationPrecision is set if value doesn't fit numeric(precision, scale) column
+	ValidationPrecision = "precision"
+	// ValidationCheck is set if value violates CHECK constraint of table or domain
+	ValidationCheck = "check"
+	// ValidationFormat is set if network address or mask of inet, cidr or macaddr column is invalid
+	ValidationFormat = "format"
+)
+
+// FieldError is a failed validation rule of column
+type FieldError struct {
+	Field   string
+	Code    string
+	Message string
+}
+
+func (e FieldError) Error() string {
+	return e.Field + " " + e.Message
+}
+
+// ValidationErrors lists all failed validation rules of model
+type ValidationErrors []FieldError
+
+func (e ValidationErrors) Error() string {
+	messages := make([]string, len(e))
+	for i, err := range e {
+		messages[i] = err.Error()
+	}
+
+	return "validation failed: " + strings.Join(messages, "; ")
+}
+
+// Field gets failed rules of column
+func (e ValidationErrors) Field(name string) []FieldError {
+	var result []FieldError
+	for _, err := range e {
+		if err.Field == name {
+			result = append(result, err)
+		}
+	}
+
+	return result
+}
+
+func isZero(value interface{}) bool {
+	v := reflect.ValueOf(value)
+	return !v.IsValid() || v.IsZero()
+}
+
+func tooLong(value string, length int) bool {
+	return charLength(value) > length
+}
+
+func charLength(value string) int {
+	return utf8.RuneCountInString(value)
+}
+
+func trimSpaces(value string) string {
+	return strings.Trim(value, " ")
+}
+
+func toLower(value string) string {
+	return strings.ToLower(value)
+}
+
+func toUpper(value string) string {
+	return strings.ToUpper(value)
+}
+
+// nonNulls counts not NULL values like num_nonnulls
+func nonNulls(notNull ...bool) int {
+	n := 0
+	for _, v := range notNull {
+		if v {
+			n++
+		}
+	}
+
+	return n
+}
+
+// patterns caches compiled patterns of CHECK constraints, they are verified on generation
+var patterns sync.Map
+
+func matches(value, pattern string) bool {
+	re, ok := patterns.Load(pattern)
+	if !ok {
+		re, _ = patterns.LoadOrStore(pattern, regexp.MustCompile(pattern))
+	}
+
+	return re.(*regexp.Regexp).MatchString(value)
+}
+
+func oneOf(value string, values ...string) bool {
+	for _, v := range values {
+		if value == v {
+			return true
+		}
+	}
+
+	return false
+}
+
+// fitsNumeric checks if value rounded to scale has at most precision-scale integer digits
+func fitsNumeric(value float64, precision, scale int) bool {
+	rounded := math.Round(value*math.Pow10(scale)) / math.Pow10(scale)
+	return math.Abs(rounded) < math.Pow10(precision-scale)
+}
+`
+
+// ValidationModel is a file with Validate methods of entities, it's generated by validation generator
+const ValidationModel = `//nolint
+//lint:file-ignore U1000 ignore unused code, it's generated
+package {{.Package}}
+` + validationModels
@@ -0,0 +1,161 @@
+package model
+
+import (
+	"bytes"
+	"go/format"
+	"strings"
+	"testing"
+	"text/template"
+
+	"github.com/ant31/bungen/generators/model/templates"
+	"github.com/ant31/bungen/model"
+	"github.com/ant31/bungen/util"
+)
+
+// testEntities returns entities covering features used by templates
+func testEntities() []model.Entity {
+	id := model.NewColumn("userId", model.TypePGInt8, false, false, false, 0, true, false, 0, nil, nil)
+	id.Default = "nextval('users_id_seq'::regclass)"
+	users := model.NewEntity(util.PublicSchema, "users", []model.Column{
+		id,
+		model.NewColumn("email", model.TypePGVarchar, false, false, false, 0, false, false, 64, nil, nil),
+		model.NewColumn("name", model.TypePGText, true, false, false, 0, false, false, 0, nil, nil),
+	}, nil)
+	users.AddIndex(model.NewIndex("users_pkey", []string{"userId"}, true, true, "btree", false, false))
+	users.AddIndex(model.NewIndex("users_email_key", []string{"email"}, true, false, "btree", false, false))
+	users.AddConstraint(model.NewConstraint("users_email_key", model.ConstraintUnique, []string{"email"}, "UNIQUE (email)"))
+
+	roles := model.NewEntity(util.PublicSchema, "user_roles", []model.Column{
+		model.NewColumn("userId", model.TypePGInt8, false, false, false, 0, true, true, 0, nil, nil),
+		model.NewColumn("role", model.TypePGText, false, false, false, 0, true, false, 0, nil, nil),
+	}, nil)
+
+	return []model.Entity{users, roles}
+}
+
+func renderTemplate(t *testing.T, tpl string, entities []model.Entity, options Options) string {
+	t.Helper()
+
+	parsed, err := template.New("base").Parse(tpl)
+	if err != nil {
+		t.Fatalf("parsing template error: %v", err)
+	}
+
+	var buffer bytes.Buffer
+	if err := parsed.ExecuteTemplate(&buffer, "base", NewTemplatePackage(entities, options)); err != nil {
+		t.Fatalf("executing template error: %v", err)
+	}
+
+	formatted, err := format.Source(buffer.Bytes())
+	if err != nil {
+		t.Fatalf("generated code is invalid: %v\n%s", err, buffer.String())
+	}
+
+	return string(formatted)
+}
+
+func TestTemplates(t *testing.T) {
+	options := Options{Package: "model", WithORM: true, WithSearch: true, WithValidation: true, DBWrapName: "DBWrap"}
+	options.Def()
+
+	tests := []struct {
+		name     string
+		template string
+		want     []string
+	}{
+		{
+			name:     "Should generate tables",
+			template: templates.Tables,
+			want: []string{
+				"var UserRoleT = UserRoleTable{",
+				"type Column struct",
+				"var UserColumns = UserColumnSet{",
+				`Email: Column{table: UserT.Table, name: "email"},`,
+			},
+		},
+		{
+			name:     "Should generate orm helpers",
+			template: templates.ORM,
+			want: []string{
+				"type DBWrap struct {\n\tbun.IDB\n}",
+				"func (w *DBWrap) WithTx(tx bun.Tx) *DBWrap",
+				"func (w *DBWrap) RunInTx(ctx context.Context, opts *sql.TxOptions, fn func(repo *DBWrap) error) error",
+				"func upsert(", "type Cursor string", "func keyset(",
+			},
+		},
+		{
+			name:     "Should generate search",
+			template: templates.Search,
+			want: []string{
+				"type Searcher interface", "func (s *search) WithOr(searchers ...Searcher)",
+				"\tif len(items) == 0 {\n\t\treturn fmt.Errorf(\"%w: no value\", ErrInvalidValue)\n\t}\n",
+			},
+		},
+		{
+			name:     "Should generate validation",
+			template: templates.Validation,
+			want:     []string{"type ValidationErrors []FieldError", "func fitsNumeric(value float64, precision, scale int) bool"},
+		},
+		{
+			name:     "Should generate constraint errors",
+			template: templates.Errors,
+			want: []string{
+				`ErrUserEmailUnique = &Constraint{Schema: "public", Table: "users", Name: "users_email_key", Kind: ConstraintUnique, Columns: []string{"email"}}`,
+				`{"public", "users", "users_email_key"}: ErrUserEmailUnique,`,
+				`ErrUserPK          = &Constraint{Schema: "public", Table: "users", Name: "users_pkey", Kind: ConstraintPK, Columns: []string{"userId"}}`,
+				`"23505": {ConstraintUnique, ConstraintPK},`,
+				"func TranslateError(err error) error",
+			},
+		},
+		{
+			name:     "Should generate search models",
+			template: templates.SearchModel,
+			want: []string{
+				"type UserSearch struct",
+				"func (s *UserSearch) Apply(query bun.QueryBuilder) bun.QueryBuilder",
+				"func ParseUserSearch(values url.Values) (*UserSearch, error)",
+			},
+		},
+		{
+			name:     "Should generate validation models",
+			template: templates.ValidationModel,
+			want:     []string{"func (m *User) Validate() error", "func (m *UserRole) Validate() error"},
+		},
+		{
+			name:     "Should generate models with repositories",
+			template: templates.Model,
+			want: []string{
+				"func (UserRepo) GetByPK(ctx context.Context, db bun.IDB, pk int64, relations ...string) (*User, error)",
+				"func (UserRepo) GetByEmail(ctx context.Context, db bun.IDB, email string, relations ...string) (*User, error)",
+				"func (UserRepo) UpsertByEmail(ctx context.Context, db bun.IDB, m *User, columns ...string) error",
+				"type UserRolePK struct",
+				"func (m *UserRole) PK() UserRolePK",
+				"func (UserRoleRepo) GetByPKs(ctx context.Context, db bun.IDB, pks []UserRolePK, relations ...string) ([]*UserRole, error)",
+				`Where("(?TableAlias.?, ?TableAlias.?) IN (?)"`,
+				"type UserPage struct",
+				"func (s *UserSearch) Apply(query bun.QueryBuilder) bun.QueryBuilder",
+				`s.op(query, UserT.Table.Ref(), Columns.User.Email, "LIKE", likePrefix(*s.EmailPrefix))`,
+				"s.in(query, UserT.Table.Ref(), Columns.User.Email, s.EmailIn, false)",
+				"func ParseUserSort(value string) ([]UserSort, error)",
+				"func ParseUserSearch(values url.Values) (*UserSearch, error)",
+				"&search.EmailIn,",
+				"case UserSortByID, UserSortByEmail, UserSortByName:",
+				"s.columns(query, UserT.Table.Ref(), s.Fields, Columns.User.ID, Columns.User.Email)",
+				"func (UserRepo) PageByEmail(ctx context.Context, db bun.IDB, search *UserSearch, cursor Cursor, limit int, direction PageDirection, relations ...string) (*UserPage, error)",
+				"func (m *User) Validate() error",
+				`errs = append(errs, FieldError{Field: Columns.User.Email, Code: ValidationMaxLength, Message: "must be at most 64 characters long"})`,
+				"func (UserRoleRepo) Page(ctx context.Context, db bun.IDB, search *UserRoleSearch, cursor Cursor, limit int, direction PageDirection, relations ...string) (*UserRolePage, error)",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := renderTemplate(t, tt.template, testEntities(), options)
+			for _, want := range tt.want {
+				if !strings.Contains(got, want) {
+					t.Errorf("generated code does not contain %q", want)
+				}
+			}
+		})
+	}
+}
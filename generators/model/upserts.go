@@ -0,0 +1,89 @@
+package model
+
+import (
+	"strings"
+
+	"github.com/ant31/bungen/model"
+)
+
+// TemplateUpsert stores upsert method generated from primary key or unique index
+type TemplateUpsert struct {
+	// Name is a method name suffix, e.g. ByEmail, empty for primary key
+	Name    string
+	Index   string
+	Columns []TemplateColumn
+}
+
+// newTemplateUpserts generates upserts for primary key and every unique index
+func newTemplateUpserts(entity model.Entity, columns []TemplateColumn) []TemplateUpsert {
+	byName := map[string]TemplateColumn{}
+	var pks []TemplateColumn
+	for _, column := range columns {
+		byName[column.PGName] = column
+		if column.IsPK {
+			pks = append(pks, column)
+		}
+	}
+
+	var upserts []TemplateUpsert
+	if len(pks) > 0 {
+		upserts = append(upserts, TemplateUpsert{Index: "primary key", Columns: pks})
+	}
+
+	for _, index := range entity.UniqueIndexes() {
+		if columnSet(index.Columns) == columnSet(pkNames(pks)) {
+			continue
+		}
+
+		cols := make([]TemplateColumn, 0, len(index.Columns))
+		goNames := make([]string, 0, len(index.Columns))
+		for _, name := range index.Columns {
+			if column, ok := byName[name]; ok {
+				cols = append(cols, column)
+				goNames = append(goNames, column.GoName)
+			}
+		}
+
+		if len(cols) == len(index.Columns) {
+			upserts = append(upserts, TemplateUpsert{
+				Name:    "By" + strings.Join(goNames, "And"),
+				Index:   index.Name,
+				Columns: cols,
+			})
+		}
+	}
+
+	return upserts
+}
+
+// upsertColumns returns columns updated on conflict by default:
+// primary keys, generated columns, columns having default and version are excluded,
+// upsert doesn't check version, so it's left as is, creation time and creator filled by hook are kept too
+func upsertColumns(columns []TemplateColumn, hook *TemplateHook) []TemplateColumn {
+	var created []string
+	if hook != nil {
+		for _, column := range append(append([]TemplateHookColumn{}, hook.CreatedAt...), hook.CreatedBy...) {
+			created = append(created, column.PGName)
+		}
+	}
+
+	var result []TemplateColumn
+	for _, column := range columns {
+		if column.IsPK || column.IsGenerated || column.HasDefault() || column.Version || column.GoType == model.TypeInterface {
+			continue
+		}
+		if contains(created, column.PGName) {
+			continue
+		}
+		result = append(result, column)
+	}
+	return result
+}
+
+func pkNames(pks []TemplateColumn) []string {
+	names := make([]string, len(pks))
+	for i, column := range pks {
+		names[i] = column.PGName
+	}
+	return names
+}
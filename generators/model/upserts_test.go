@@ -0,0 +1,69 @@
+package model
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/ant31/bungen/model"
+	"github.com/ant31/bungen/util"
+)
+
+func Test_newTemplateUpserts(t *testing.T) {
+	id := model.NewColumn("userId", model.TypePGInt8, false, false, false, 0, true, false, 0, nil, nil)
+	id.Default = "nextval('users_id_seq'::regclass)"
+	createdAt := model.NewColumn("createdAt", model.TypePGTimestamptz, false, false, false, 0, false, false, 0, nil, nil)
+	createdAt.Default = "now()"
+	search := model.NewColumn("search", model.TypePGText, true, false, false, 0, false, false, 0, nil, nil)
+	search.IsGenerated = true
+
+	columns := []model.Column{
+		id,
+		model.NewColumn("email", model.TypePGVarchar, false, false, false, 0, false, false, 0, nil, nil),
+		model.NewColumn("name", model.TypePGText, true, false, false, 0, false, false, 0, nil, nil),
+		createdAt,
+		search,
+	}
+
+	entity := model.NewEntity(util.PublicSchema, "users", columns, nil)
+	entity.AddIndex(model.NewIndex("users_pkey", []string{"userId"}, true, true, "btree", false, false))
+	entity.AddIndex(model.NewIndex("users_email_key", []string{"email"}, true, false, "btree", false, false))
+	entity.AddIndex(model.NewIndex("users_name_key", []string{"name"}, true, false, "btree", true, false))
+
+	templateEntity := NewTemplateEntity(entity, Options{})
+
+	names := make([]string, len(templateEntity.Upserts))
+	for i, upsert := range templateEntity.Upserts {
+		names[i] = upsert.Name
+	}
+	if want := []string{"", "ByEmail"}; !reflect.DeepEqual(names, want) {
+		t.Errorf("NewTemplateEntity().Upserts = %v, want %v", names, want)
+	}
+
+	updated := make([]string, len(templateEntity.UpsertColumns))
+	for i, column := range templateEntity.UpsertColumns {
+		updated[i] = column.PGName
+	}
+	if want := []string{"email", "name"}; !reflect.DeepEqual(updated, want) {
+		t.Errorf("NewTemplateEntity().UpsertColumns = %v, want %v", updated, want)
+	}
+
+	// columns filled by hook on insert keep values of existing row
+	hooked := model.NewEntity(util.PublicSchema, "users", append(columns,
+		model.NewColumn("created_at", model.TypePGTimestamptz, false, false, false, 0, false, false, 0, nil, nil),
+		model.NewColumn("created_by", model.TypePGInt8, true, false, false, 0, false, false, 0, nil, nil),
+		model.NewColumn("updated_by", model.TypePGInt8, true, false, false, 0, false, false, 0, nil, nil),
+	), nil)
+	options := Options{WithHooks: true}
+	options.Def()
+	updated = nil
+	for _, column := range NewTemplateEntity(hooked, options).UpsertColumns {
+		updated = append(updated, column.PGName)
+	}
+	if want := []string{"email", "name", "updated_by"}; !reflect.DeepEqual(updated, want) {
+		t.Errorf("NewTemplateEntity().UpsertColumns with hook = %v, want %v", updated, want)
+	}
+
+	if got, want := string(templateEntity.Columns[4].Tag), "`bun:\"search,scanonly\"`"; got != want {
+		t.Errorf("NewTemplateEntity().Columns[4].Tag = %v, want %v", got, want)
+	}
+}
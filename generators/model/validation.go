@@ -0,0 +1,118 @@
+package model
+
+import (
+	"fmt"
+	"html/template"
+	"strconv"
+	"strings"
+
+	"github.com/ant31/bungen/model"
+)
+
+// TemplateCheck stores validation rule of column
+type TemplateCheck struct {
+	Column TemplateColumn
+	// Cond is a go expression which is true if value is invalid
+	Cond template.HTML
+	// Code is a name of validation code constant, e.g. ValidationRequired
+	Code string
+	// Message is a quoted go string describing failed rule
+	Message template.HTML
+}
+
+// sqlNullValues are fields holding values of database/sql nullable types
+var sqlNullValues = map[string]string{
+	"sql.NullString":  "String",
+	"sql.NullInt64":   "Int64",
+	"sql.NullFloat64": "Float64",
+	"sql.NullBool":    "Bool",
+}
+
+// checkValue gets expression of column value in Validate method
+// guard is a condition prefix skipping NULL values, ok is false if value can't be accessed
+func checkValue(column TemplateColumn) (guard, value string, ok bool) {
+	field := "m." + column.GoName
+
+	switch {
+	case column.IsArray || column.GoType == model.TypeInterface:
+		return "", "", false
+	case column.Type == column.GoType:
+		return "", field, true
+	case column.Type == "*"+column.GoType:
+		return field + " != nil && ", "*" + field, true
+	}
+
+	if name, ok := sqlNullValues[column.Type]; ok {
+		return field + ".Valid && ", field + "." + name, true
+	}
+	// bun.NullTime has no Valid field, zero time is NULL
+	if column.Type == "bun.NullTime" {
+		return "!" + field + ".IsZero() && ", field + ".Time", true
+	}
+
+	return "", "", false
+}
+
+// newTemplateChecks generates validation rules from columns metadata:
+// NOT NULL without default, maximum length, enum labels and numeric precision
+func newTemplateChecks(columns []TemplateColumn) []TemplateCheck {
+	var checks []TemplateCheck
+	add := func(column TemplateColumn, code, cond, message string) {
+		checks = append(checks, TemplateCheck{
+			Column:  column,
+			Cond:    template.HTML(cond),
+			Code:    code,
+			Message: template.HTML(strconv.Quote(message)),
+		})
+	}
+
+	for _, column := range columns {
+		if column.GoType == model.TypeInterface {
+			continue
+		}
+
+		// zero values are inserted as NULL, see nullzero tag
+		if !column.Nullable && !column.IsPK && !column.HasDefault() && !column.IsGenerated && column.SoftDelete == "" && !column.Version {
+			add(column, "ValidationRequired", fmt.Sprintf("isZero(m.%s)", column.GoName), "is required")
+		}
+
+		guard, value, ok := checkValue(column)
+		if !ok {
+			continue
+		}
+
+		switch {
+		case len(column.Values) > 0 && column.GoType == model.TypeString:
+			values := make([]string, len(column.Values))
+			for i, v := range column.Values {
+				values[i] = strconv.Quote(v)
+			}
+			add(column, "ValidationEnum",
+				fmt.Sprintf("%s!oneOf(%s, %s)", guard, value, strings.Join(values, ", ")),
+				fmt.Sprintf("must be one of %s", strings.Join(column.Values, ", ")))
+		case column.MaxLen > 0 && column.GoType == model.TypeString:
+			add(column, "ValidationMaxLength",
+				fmt.Sprintf("%stooLong(%s, %d)", guard, value, column.MaxLen),
+				fmt.Sprintf("must be at most %d characters long", column.MaxLen))
+		case isNetIP(column.Column):
+			// empty addresses of not null columns are reported as required
+			if guard == "" {
+				guard = fmt.Sprintf("!isZero(%s) && ", value)
+			}
+			// methods of network types are called on pointers as well
+			add(column, "ValidationFormat",
+				fmt.Sprintf("%sm.%s.Validate() != nil", guard, column.GoName),
+				fmt.Sprintf("must be valid %s", column.PGType))
+		case column.PGType == model.TypePGNumeric && column.Precision > 0 && isFloat(column.GoType):
+			add(column, "ValidationPrecision",
+				fmt.Sprintf("%s!fitsNumeric(float64(%s), %d, %d)", guard, value, column.Precision, column.Scale),
+				fmt.Sprintf("must fit numeric(%d,%d)", column.Precision, column.Scale))
+		}
+	}
+
+	return checks
+}
+
+func isFloat(goType string) bool {
+	return goType == model.TypeFloat32 || goType == model.TypeFloat64
+}
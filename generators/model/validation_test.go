@@ -0,0 +1,113 @@
+package model
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/ant31/bungen/generators/base"
+	"github.com/ant31/bungen/model"
+	"github.com/ant31/bungen/util"
+)
+
+func Test_newTemplateChecks(t *testing.T) {
+	id := model.NewColumn("userId", model.TypePGInt8, false, false, false, 0, true, false, 0, nil, nil)
+	id.Default = "nextval('users_id_seq'::regclass)"
+	created := model.NewColumn("createdAt", model.TypePGTimestamptz, false, false, false, 0, false, false, 0, nil, nil)
+	created.Default = "now()"
+	balance := model.NewColumn("balance", model.TypePGNumeric, true, false, false, 0, false, false, 0, nil, nil)
+	balance.Precision, balance.Scale = 10, 2
+
+	entity := model.NewEntity(util.PublicSchema, "users", []model.Column{
+		id,
+		model.NewColumn("email", model.TypePGVarchar, false, false, false, 0, false, false, 64, nil, nil),
+		model.NewColumn("name", model.TypePGVarchar, true, true, false, 0, false, false, 32, nil, nil),
+		model.NewColumn("status", model.TypePGVarchar, true, false, false, 0, false, false, 0, []string{"active", "banned"}, nil),
+		model.NewColumn("tags", model.TypePGVarchar, false, false, true, 1, false, false, 16, nil, nil),
+		created,
+		balance,
+	}, nil)
+
+	checks := NewTemplateEntity(entity, Options{}).Checks
+
+	got := make([]string, len(checks))
+	for i, check := range checks {
+		got[i] = check.Column.GoName + " " + check.Code + ": " + string(check.Cond)
+	}
+
+	want := []string{
+		"Email ValidationRequired: isZero(m.Email)",
+		"Email ValidationMaxLength: tooLong(m.Email, 64)",
+		"Name ValidationMaxLength: m.Name.Valid && tooLong(m.Name.String, 32)",
+		`Status ValidationEnum: m.Status != nil && !oneOf(*m.Status, "active", "banned")`,
+		"Tags ValidationRequired: isZero(m.Tags)",
+		"Balance ValidationPrecision: m.Balance != nil && !fitsNumeric(float64(*m.Balance), 10, 2)",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("newTemplateChecks() = %#v, want %#v", got, want)
+	}
+}
+
+func Test_newTemplateChecks_netIP(t *testing.T) {
+	ct := model.CustomTypeMapping{}
+	for pgType, goType := range base.NetIPTypes {
+		ct.Add(pgType, goType, "")
+	}
+
+	entity := model.NewEntity(util.PublicSchema, "hosts", []model.Column{
+		model.NewColumn("ip", model.TypePGInet, true, false, false, 0, false, false, 0, nil, ct),
+		model.NewColumn("mac", model.TypePGMacaddr, false, false, false, 0, false, false, 0, nil, ct),
+	}, nil)
+
+	checks := NewTemplateEntity(entity, Options{}).Checks
+
+	got := make([]string, len(checks))
+	for i, check := range checks {
+		got[i] = check.Column.GoName + " " + check.Code + ": " + string(check.Cond)
+	}
+
+	want := []string{
+		"IP ValidationFormat: m.IP != nil && m.IP.Validate() != nil",
+		"Mac ValidationRequired: isZero(m.Mac)",
+		"Mac ValidationFormat: !isZero(m.Mac) && m.Mac.Validate() != nil",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("newTemplateChecks() = %#v, want %#v", got, want)
+	}
+}
+
+func Test_checkValue(t *testing.T) {
+	entity := model.NewEntity(util.PublicSchema, "users", nil, nil)
+	tests := []struct {
+		name      string
+		column    model.Column
+		wantGuard string
+		wantValue string
+	}{
+		{
+			name:      "Should access pointer",
+			column:    model.NewColumn("loggedAt", model.TypePGTimestamptz, true, false, false, 0, false, false, 0, nil, nil),
+			wantGuard: "m.LoggedAt != nil && ",
+			wantValue: "*m.LoggedAt",
+		},
+		{
+			name:      "Should access sql null",
+			column:    model.NewColumn("name", model.TypePGText, true, true, false, 0, false, false, 0, nil, nil),
+			wantGuard: "m.Name.Valid && ",
+			wantValue: "m.Name.String",
+		},
+		{
+			name:      "Should access bun null time",
+			column:    model.NewColumn("loggedAt", model.TypePGTimestamptz, true, true, false, 0, false, false, 0, nil, nil),
+			wantGuard: "!m.LoggedAt.IsZero() && ",
+			wantValue: "m.LoggedAt.Time",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			guard, value, ok := checkValue(NewTemplateColumn(entity, tt.column, Options{}))
+			if !ok || guard != tt.wantGuard || value != tt.wantValue {
+				t.Errorf("checkValue() = %q, %q, %v, want %q, %q", guard, value, ok, tt.wantGuard, tt.wantValue)
+			}
+		})
+	}
+}
@@ -0,0 +1,52 @@
+package model
+
+import (
+	"github.com/ant31/bungen/model"
+	"github.com/ant31/bungen/util"
+)
+
+// NoVersion disables optimistic locking of table in options
+const NoVersion = "-"
+
+// versionColumn finds optimistic locking column name of entity
+// table option goes first, then conventional names
+func versionColumn(entity model.Entity, options Options) string {
+	for _, table := range []string{util.Join(entity.PGSchema, entity.PGName), util.JoinF(entity.PGSchema, entity.PGName)} {
+		if name, ok := options.Versions[table]; ok {
+			if name == NoVersion {
+				return ""
+			}
+			return name
+		}
+	}
+
+	for _, name := range options.VersionNames {
+		for _, column := range entity.Columns {
+			if column.PGName == name && isVersionable(column) {
+				return name
+			}
+		}
+	}
+
+	return ""
+}
+
+// isVersionable checks if column can hold version of row: not null integer updated by queries
+func isVersionable(column model.Column) bool {
+	if column.IsArray || column.IsGenerated || column.IsPK || column.Nullable {
+		return false
+	}
+
+	return column.GoType == model.TypeInt || column.GoType == model.TypeInt32 || column.GoType == model.TypeInt64
+}
+
+// newTemplateVersion gets optimistic locking column of entity, returns nil if entity has no version column
+func newTemplateVersion(columns []TemplateColumn) *TemplateColumn {
+	for i := range columns {
+		if columns[i].Version {
+			return &columns[i]
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,126 @@
+package model
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ant31/bungen/generators/model/templates"
+	"github.com/ant31/bungen/model"
+	"github.com/ant31/bungen/util"
+)
+
+func versionTestEntities() []model.Entity {
+	id := func() model.Column {
+		return model.NewColumn("id", model.TypePGInt8, false, false, false, 0, true, false, 0, nil, nil)
+	}
+
+	return []model.Entity{
+		model.NewEntity(util.PublicSchema, "users", []model.Column{
+			id(),
+			model.NewColumn("email", model.TypePGText, false, false, false, 0, false, false, 0, nil, nil),
+			model.NewColumn("lock_version", model.TypePGInt4, false, false, false, 0, false, false, 0, nil, nil),
+		}, nil),
+		model.NewEntity(util.PublicSchema, "projects", []model.Column{
+			id(),
+			// nullable column can't be compared with version of model
+			model.NewColumn("version", model.TypePGInt4, true, false, false, 0, false, false, 0, nil, nil),
+			model.NewColumn("revision", model.TypePGInt8, false, false, false, 0, false, false, 0, nil, nil),
+		}, nil),
+	}
+}
+
+func Test_newTemplateVersion(t *testing.T) {
+	tests := []struct {
+		name    string
+		options Options
+		want    []string
+	}{
+		{
+			name:    "Should detect conventional columns",
+			options: Options{VersionNames: []string{"version", "lock_version"}},
+			want:    []string{"lock_version", ""},
+		},
+		{
+			name:    "Should use table column",
+			options: Options{VersionNames: []string{"version", "lock_version"}, Versions: map[string]string{"public.projects": "revision", "users": "-"}},
+			want:    []string{"", "revision"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for i, entity := range versionTestEntities() {
+				got := ""
+				if version := NewTemplateEntity(entity, tt.options).Version; version != nil {
+					got = version.PGName
+				}
+				if got != tt.want[i] {
+					t.Errorf("newTemplateVersion() %s = %q, want %q", entity.PGName, got, tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestVersionTemplates(t *testing.T) {
+	options := Options{Package: "model", WithORM: true, WithValidation: true, DBWrapName: "DBWrap"}
+	options.Def()
+
+	models := renderTemplate(t, templates.Model, versionTestEntities(), options)
+	for _, want := range []string{
+		"`bun:\"lock_version\"`",
+		"\t\tq = q.Column(columns...).Column(Columns.User.LockVersion)\n",
+		"\tversion := m.LockVersion\n\tm.LockVersion++\n\tres, err := q.Where(\"?TableAlias.? = ?\", bun.Ident(Columns.User.LockVersion), version).Exec(ctx)\n\tif err = stale(res, err, 1); err != nil {\n\t\tm.LockVersion = version\n\t}\n",
+		"\tversions := make([]int, len(list))\n\tfor i, m := range list {\n\t\tversions[i] = m.LockVersion\n\t\tm.LockVersion++\n\t}\n",
+		"Where(\"?TableAlias.? = _data.? - 1\", bun.Ident(Columns.User.LockVersion), bun.Ident(Columns.User.LockVersion)).Exec(ctx)\n\tif err = stale(res, err, len(list)); err != nil {\n\t\tfor i, m := range list {\n\t\t\tm.LockVersion = versions[i]\n\t\t}\n\t}\n\n\treturn err\n",
+		"\tq := db.NewDelete().Model(m).WherePK()\n\n\tres, err := q.Where(\"?TableAlias.? = ?\", bun.Ident(Columns.User.LockVersion), m.LockVersion).Exec(ctx)\n\treturn stale(res, err, 1)",
+		"\t\tvalues[i] = []interface{}{m.ID, m.LockVersion}\n",
+		"func (UserRepo) DeleteByPK(ctx context.Context, db bun.IDB, pk int64, version int) error {",
+	} {
+		if !strings.Contains(models, want) {
+			t.Errorf("generated models do not contain %q", want)
+		}
+	}
+
+	if strings.Contains(models, "isZero(m.LockVersion)") {
+		t.Errorf("generated models require version column")
+	}
+
+	orm := renderTemplate(t, templates.ORM, versionTestEntities(), options)
+	if !strings.Contains(orm, "func stale(res sql.Result, err error, rows int) error {") {
+		t.Errorf("generated orm does not contain stale")
+	}
+}
+
+func TestVersionTemplates_softDelete(t *testing.T) {
+	options := Options{Package: "model", WithORM: true, DBWrapName: "DBWrap"}
+	options.Def()
+
+	users := versionTestEntities()[0]
+	users.AddColumn(model.NewColumn("is_deleted", model.TypePGBool, false, false, false, 0, false, false, 0, nil, nil))
+	projects := model.NewEntity(util.PublicSchema, "projects", []model.Column{
+		model.NewColumn("id", model.TypePGInt8, false, false, false, 0, true, false, 0, nil, nil),
+		model.NewColumn("deleted_at", model.TypePGTimestamptz, true, false, false, 0, false, false, 0, nil, nil),
+		model.NewColumn("version", model.TypePGInt4, false, false, false, 0, false, false, 0, nil, nil),
+	}, nil)
+
+	models := renderTemplate(t, templates.Model, []model.Entity{users, projects}, options)
+	for _, want := range []string{
+		"\tm.IsDeleted = true\n\tq := db.NewUpdate().Model(m).Column(Columns.User.IsDeleted, Columns.User.LockVersion).WherePK().",
+		"\tversion := m.LockVersion\n\tm.LockVersion++\n",
+		"\tfor i, m := range list {\n\t\tm.IsDeleted = deleted\n\t\tversions[i] = m.LockVersion\n\t\tm.LockVersion++\n\t}\n",
+		"Column(Columns.User.IsDeleted, Columns.User.LockVersion).Bulk().\n\t\tWhere(\"?TableAlias.? = _data.? - 1\"",
+		// bun soft delete doesn't increment version
+		"\tnow := time.Now()\n\tm.DeletedAt = &now\n\tq := db.NewUpdate().Model(m).Column(Columns.Project.DeletedAt, Columns.Project.Version).WherePK()\n",
+	} {
+		if !strings.Contains(models, want) {
+			t.Errorf("generated models do not contain %q", want)
+		}
+	}
+
+	// upsert doesn't check version
+	for _, column := range NewTemplateEntity(users, options).UpsertColumns {
+		if column.Version {
+			t.Errorf("UpsertColumns contain version")
+		}
+	}
+}
@@ -0,0 +1,34 @@
+package named
+
+import (
+	"github.com/ant31/bungen/generators/base"
+	genmodel "github.com/ant31/bungen/generators/model"
+	"github.com/ant31/bungen/generators/model/templates"
+
+	"github.com/spf13/cobra"
+)
+
+// CreateCommand creates generator command
+func CreateCommand() *cobra.Command {
+	return base.CreateCommand("model-named", "Named columns and tables generator for bun[postgres] models", New())
+}
+
+// Named represents generator of named columns and tables structs
+type Named struct {
+	*genmodel.Basic
+}
+
+// New creates named generator
+func New() *Named {
+	return &Named{Basic: genmodel.New()}
+}
+
+// Generate runs whole generation process
+func (g *Named) Generate() error {
+	entities, err := g.Read()
+	if err != nil {
+		return err
+	}
+
+	return g.GenerateOnce(entities, "Tables", templates.Tables, "tables.gen.go")
+}
@@ -0,0 +1,62 @@
+package search
+
+import (
+	"github.com/ant31/bungen/generators/base"
+	genmodel "github.com/ant31/bungen/generators/model"
+	"github.com/ant31/bungen/generators/model/templates"
+
+	"github.com/spf13/cobra"
+)
+
+// CreateCommand creates generator command
+func CreateCommand() *cobra.Command {
+	return base.CreateCommand("search", "Search structs generator for bun[postgres] models", New())
+}
+
+// Search represents search structs generator
+type Search struct {
+	*genmodel.Basic
+}
+
+// New creates search generator
+func New() *Search {
+	return &Search{Basic: genmodel.New()}
+}
+
+// ReadFlags read flags from command, search is always enabled
+func (g *Search) ReadFlags(command *cobra.Command) error {
+	if err := g.Basic.ReadFlags(command); err != nil {
+		return err
+	}
+
+	options := g.Options()
+	options.WithSearch = true
+	g.SetOptions(options)
+
+	return nil
+}
+
+// Generate runs whole generation process
+func (g *Search) Generate() error {
+	entities, err := g.Read()
+	if err != nil {
+		return err
+	}
+
+	err = g.GenerateOnce(entities, "Tables", templates.Tables, "tables.gen.go")
+	if err != nil {
+		return err
+	}
+
+	err = g.GenerateTypes(entities)
+	if err != nil {
+		return err
+	}
+
+	err = g.GenerateOnce(entities, "Search", templates.Search, "search.gen.go")
+	if err != nil {
+		return err
+	}
+
+	return g.GeneratePerEntity(entities, "Search", templates.SearchModel, ".search")
+}
@@ -0,0 +1,57 @@
+package validate
+
+import (
+	"github.com/ant31/bungen/generators/base"
+	genmodel "github.com/ant31/bungen/generators/model"
+	"github.com/ant31/bungen/generators/model/templates"
+
+	"github.com/spf13/cobra"
+)
+
+// CreateCommand creates generator command
+func CreateCommand() *cobra.Command {
+	return base.CreateCommand("validation", "Validate functions generator for bun[postgres] models", New())
+}
+
+// Validate represents validate functions generator
+type Validate struct {
+	*genmodel.Basic
+}
+
+// New creates validate generator
+func New() *Validate {
+	return &Validate{Basic: genmodel.New()}
+}
+
+// ReadFlags read flags from command, validation is always enabled
+func (g *Validate) ReadFlags(command *cobra.Command) error {
+	if err := g.Basic.ReadFlags(command); err != nil {
+		return err
+	}
+
+	options := g.Options()
+	options.WithValidation = true
+	g.SetOptions(options)
+
+	return nil
+}
+
+// Generate runs whole generation process
+func (g *Validate) Generate() error {
+	entities, err := g.Read()
+	if err != nil {
+		return err
+	}
+
+	err = g.GenerateOnce(entities, "Tables", templates.Tables, "tables.gen.go")
+	if err != nil {
+		return err
+	}
+
+	err = g.GenerateOnce(entities, "Validation", templates.Validation, "validation.gen.go")
+	if err != nil {
+		return err
+	}
+
+	return g.GeneratePerEntity(entities, "Validation", templates.ValidationModel, ".validation")
+}
@@ -82,16 +82,61 @@ func (g *Bungen) Read(selected []string, followFK, useSQLNulls bool, customTypes
 		return nil, err
 	}
 
+	indexes, err := g.Store.Indexes(tables)
+	if err != nil {
+		return nil, err
+	}
+
+	constraints, err := g.Store.Constraints(tables)
+	if err != nil {
+		return nil, err
+	}
+
+	composites, err := g.readComposites(useSQLNulls, customTypes)
+	if err != nil {
+		return nil, err
+	}
+
+	domains, err := g.readDomains()
+	if err != nil {
+		return nil, err
+	}
+
 	entities := make([]model.Entity, len(tables))
 	index := map[string]int{}
 	for i, t := range tables {
 		index[util.Join(t.Schema, t.Name)] = i
 		entities[i] = t.Entity()
 	}
+	// different tables may get the same name, e.g. user and users, or name of identifier generated for other table
+	model.SafeEntityNames(entities)
 
 	for _, c := range columns {
 		if i, ok := index[util.Join(c.Schema, c.Table)]; ok {
-			entities[i].AddColumn(c.Column(useSQLNulls, customTypes))
+			d, isDomain := domains[util.Join(c.DomainSchema, c.DomainName)]
+			if isDomain && d.NotNull {
+				c.IsNullable = false
+			}
+
+			column := c.Column(useSQLNulls, compositeTypes(customTypes, composites, c.TypeSchema, c.Type))
+			if isDomain {
+				column.Domain = d
+			}
+			column.Composite = composites[util.Join(c.TypeSchema, c.Type)]
+
+			entities[i].AddColumn(column)
+		}
+	}
+
+	for _, ix := range indexes {
+		if i, ok := index[util.Join(ix.Schema, ix.Table)]; ok {
+			entities[i].AddIndex(ix.Index())
+		}
+	}
+
+	for _, co := range constraints {
+		if i, ok := index[util.Join(co.Schema, co.Table)]; ok {
+			entities[i].AddConstraint(co.Constraint())
 		}
 	}
 
@@ -99,6 +144,7 @@ func (g *Bungen) Read(selected []string, followFK, useSQLNulls bool, customTypes
 		rel := r.Relation()
 		if i, ok := index[util.Join(r.TargetSchema, r.TargetTable)]; ok {
 			rel.AddEntity(&entities[i])
+			rel.GoType = entities[i].GoName
 		}
 		if i, ok := index[util.Join(r.SourceSchema, r.SourceTable)]; ok {
 			entities[i].AddRelation(rel)
@@ -106,3 +152,86 @@ func (g *Bungen) Read(selected []string, followFK, useSQLNulls bool, customTypes
 	}
 	return entities, nil
 }
+
+// readComposites reads user-defined composite types indexed by full name
+// composite is used as column type unless custom type is set for its name
+func (g *Bungen) readComposites(useSQLNulls bool, customTypes model.CustomTypeMapping) (map[string]*model.Composite, error) {
+	fields, err := g.Store.Composites()
+	if err != nil {
+		return nil, err
+	}
+
+	composites := map[string]*model.Composite{}
+	for _, f := range fields {
+		key := util.Join(f.Schema, f.Name)
+		if _, ok := composites[key]; ok || customTypes.Has(f.Name) {
+			continue
+		}
+
+		composite := model.NewComposite(f.Schema, f.Name)
+		composites[key] = &composite
+	}
+
+	// fields are added when all composites are known to resolve nested ones
+	for _, f := range fields {
+		if composite, ok := composites[util.Join(f.Schema, f.Name)]; ok {
+			field := f.Column(useSQLNulls, compositeTypes(customTypes, composites, f.TypeSchema, f.Type))
+			field.Composite = composites[util.Join(f.TypeSchema, f.Type)]
+			composite.AddField(field)
+		}
+	}
+
+	return composites, nil
+}
+
+// compositeTypes adds composite used as column type to custom types
+// composites are found by schema of column type, so types with the same name in different schemas do not collide
+func compositeTypes(customTypes model.CustomTypeMapping, composites map[string]*model.Composite, schema, pgType string) model.CustomTypeMapping {
+	composite, ok := composites[util.Join(schema, pgType)]
+	if !ok {
+		return customTypes
+	}
+
+	mapping := make(model.CustomTypeMapping, len(customTypes)+1)
+	for name, customType := range customTypes {
+		mapping[name] = customType
+	}
+	mapping.Add(pgType, composite.GoName, "")
+
+	return mapping
+}
+
+// readDomains reads user-defined domains indexed by full name
+func (g *Bungen) readDomains() (map[string]*model.Domain, error) {
+	rows, err := g.Store.Domains()
+	if err != nil {
+		return nil, err
+	}
+
+	domains := map[string]*model.Domain{}
+	for _, d := range rows {
+		domain := d.Domain()
+		domains[util.Join(d.Schema, d.Name)] = &domain
+	}
+
+	return domains, nil
+}
+
+// SampleJSON reads up to limit not null values of json column
+func (g *Bungen) SampleJSON(schema, table, column string, limit int) ([][]byte, error) {
+	if err := g.Connect(); err != nil {
+		return nil, err
+	}
+
+	rows, err := g.Store.SampleJSON(schema, table, column, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	samples := make([][]byte, len(rows))
+	for i, row := range rows {
+		samples[i] = []byte(row)
+	}
+
+	return samples, nil
+}
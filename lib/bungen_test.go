@@ -4,6 +4,9 @@ import (
 	"log"
 	"os"
 	"testing"
+
+	"github.com/ant31/bungen/model"
+	"github.com/ant31/bungen/util"
 )
 
 func prepareReq() (url string, logger *log.Logger) {
@@ -29,3 +32,25 @@ func TestBungen_Read(t *testing.T) {
 		}
 	})
 }
+
+func Test_compositeTypes(t *testing.T) {
+	public := model.NewComposite(util.PublicSchema, "address")
+	geo := model.NewComposite("geo", "address")
+	composites := map[string]*model.Composite{"public.address": &public, "geo.address": &geo}
+	customTypes := model.CustomTypeMapping{}
+	customTypes.Add("uuid", "uuid.UUID", "github.com/google/uuid")
+
+	for schema, want := range map[string]string{util.PublicSchema: "Address", "geo": "GeoAddress"} {
+		column := model.NewColumn("addr", "address", false, false, false, 0, false, false, 0, nil, compositeTypes(customTypes, composites, schema, "address"))
+		if column.GoType != want {
+			t.Errorf("compositeTypes() %s type = %v, want %v", schema, column.GoType, want)
+		}
+	}
+
+	if customTypes.Has("address") {
+		t.Errorf("compositeTypes() changed custom types")
+	}
+	if mapping := compositeTypes(customTypes, composites, util.PublicSchema, "point"); len(mapping) != 1 {
+		t.Errorf("compositeTypes() added type for column without composite")
+	}
+}
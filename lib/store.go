@@ -59,15 +59,88 @@ type column struct {
 	IsArray    bool     `bun:"is_array"`
 	Dimensions int      `bun:"dims"`
 	Type       string   `bun:"type"`
+	TypeSchema string   `bun:"type_schema"`
 	Default    string   `bun:"def"`
+	IsIdentity bool     `bun:"is_identity"`
+	Generated  bool     `bun:"is_generated"`
 	IsPK       bool     `bun:"is_pk"`
 	IsFK       bool     `bun:"is_fk"`
 	MaxLen     int      `bun:"len"`
 	Values     []string `bun:"enum,array"`
+	Precision  int      `bun:"precision"`
+	Scale      int      `bun:"scale"`
+
+	DomainSchema string `bun:"domain_schema"`
+	DomainName   string `bun:"domain_name"`
+
+	Comment string `bun:"comment"`
 }
 
 func (c column) Column(useSQLNulls bool, customTypes model.CustomTypeMapping) model.Column {
-	return model.NewColumn(c.Name, c.Type, c.IsNullable, useSQLNulls, c.IsArray, c.Dimensions, c.IsPK, c.IsFK, c.MaxLen, c.Values, customTypes)
+	col := model.NewColumn(c.Name, c.Type, c.IsNullable, useSQLNulls, c.IsArray, c.Dimensions, c.IsPK, c.IsFK, c.MaxLen, c.Values, customTypes)
+	col.Description = c.Comment
+	col.Default = c.Default
+	col.IsIdentity = c.IsIdentity
+	col.IsGenerated = c.Generated
+	col.Precision = c.Precision
+	col.Scale = c.Scale
+	return col
+}
+
+type compositeField struct {
+	Schema     string `bun:"schema_name"`
+	Name       string `bun:"type_name"`
+	Field      string `bun:"field_name"`
+	IsArray    bool   `bun:"is_array"`
+	Dimensions int    `bun:"dims"`
+	Type       string `bun:"type"`
+	TypeSchema string `bun:"type_schema"`
+}
+
+func (f compositeField) Column(useSQLNulls bool, customTypes model.CustomTypeMapping) model.Column {
+	// composite attributes can not have not null constraint
+	return model.NewColumn(f.Field, f.Type, true, useSQLNulls, f.IsArray, f.Dimensions, false, false, 0, nil, customTypes)
+}
+
+type domain struct {
+	Schema  string   `bun:"schema_name"`
+	Name    string   `bun:"domain_name"`
+	Type    string   `bun:"type"`
+	NotNull bool     `bun:"not_null"`
+	Checks  []string `bun:"checks,array"`
+}
+
+func (d domain) Domain() model.Domain {
+	return model.NewDomain(d.Schema, d.Name, d.Type, d.NotNull, d.Checks)
+}
+
+type index struct {
+	Schema         string   `bun:"schema_name"`
+	Table          string   `bun:"table_name"`
+	Name           string   `bun:"index_name"`
+	Columns        []string `bun:"columns,array"`
+	IsUnique       bool     `bun:"is_unique"`
+	IsPrimary      bool     `bun:"is_primary"`
+	Method         string   `bun:"method"`
+	IsPartial      bool     `bun:"is_partial"`
+	HasExpressions bool     `bun:"has_expressions"`
+}
+
+func (i index) Index() model.Index {
+	return model.NewIndex(i.Name, i.Columns, i.IsUnique, i.IsPrimary, i.Method, i.IsPartial, i.HasExpressions)
+}
+
+type constraint struct {
+	Schema     string   `bun:"schema_name"`
+	Table      string   `bun:"table_name"`
+	Name       string   `bun:"constraint_name"`
+	Type       string   `bun:"constraint_type"`
+	Columns    []string `bun:"columns,array"`
+	Definition string   `bun:"definition"`
+}
+
+func (c constraint) Constraint() model.Constraint {
+	return model.NewConstraint(c.Name, c.Type, c.Columns, c.Definition)
 }
 
 // Store is database helper
@@ -251,9 +324,23 @@ func (s store) Columns(tables []table) ([]column, error) {
 		                then 'varchar'
 		                else ltrim(c.udt_name, '_')
 		                end                         as type,
+		                c.udt_schema                as type_schema,
 		                c.column_default            as def,
+		                c.is_identity = 'YES'       as is_identity,
+		                c.is_generated = 'ALWAYS'   as is_generated,
                         c.character_maximum_length  as len,
-						e.enum_values 				as enum
+						e.enum_values 				as enum,
+		                case
+		                when c.udt_name = 'numeric'
+		                then c.numeric_precision
+		                end                         as precision,
+		                case
+		                when c.udt_name = 'numeric'
+		                then c.numeric_scale
+		                end                         as scale,
+		                c.domain_schema             as domain_schema,
+		                c.domain_name               as domain_name,
+		                col_description(format('%I.%I', c.table_schema, c.table_name)::regclass, c.ordinal_position::int) as comment
 		from information_schema.tables t
 		left join information_schema.columns c using (table_name, table_schema)
 		left join info i using (table_name, table_schema, column_name)
@@ -273,6 +360,158 @@ func (s store) Columns(tables []table) ([]column, error) {
 	return columns, nil
 }
 
+// Indexes gets indexes of tables, including ones backing primary key and unique constraints
+func (s *store) Indexes(tables []table) ([]index, error) {
+	ts := make([]interface{}, len(tables))
+	for i, t := range tables {
+		ts[i] = []string{t.Schema, t.Name}
+	}
+
+	query := `
+		select n.nspname                    as schema_name,
+		       t.relname                    as table_name,
+		       i.relname                    as index_name,
+		       array(
+		           select a.attname
+		           from unnest(ix.indkey::int2[]) with ordinality k(attnum, ord)
+		           join pg_attribute a on a.attrelid = t.oid and a.attnum = k.attnum
+		           where k.ord <= ix.indnkeyatts
+		           order by k.ord
+		       )                            as columns,
+		       ix.indisunique               as is_unique,
+		       ix.indisprimary              as is_primary,
+		       am.amname                    as method,
+		       ix.indpred is not null       as is_partial,
+		       ix.indexprs is not null      as has_expressions
+		from pg_index ix
+		join pg_class i on i.oid = ix.indexrelid
+		join pg_class t on t.oid = ix.indrelid
+		join pg_namespace n on n.oid = t.relnamespace
+		join pg_am am on am.oid = i.relam
+		where (n.nspname, t.relname) in (?)
+		order by 1, 2, ix.indisprimary desc, 3
+	`
+
+	var indexes []index
+	err := s.db.NewRaw(query, bun.In(ts)).Scan(context.Background(), &indexes)
+	if err != nil {
+		return nil, fmt.Errorf("getting indexes info error: %w", err)
+	}
+
+	return indexes, nil
+}
+
+// Constraints gets constraints of tables with their definitions
+func (s *store) Constraints(tables []table) ([]constraint, error) {
+	ts := make([]interface{}, len(tables))
+	for i, t := range tables {
+		ts[i] = []string{t.Schema, t.Name}
+	}
+
+	query := `
+		select n.nspname                        as schema_name,
+		       t.relname                        as table_name,
+		       co.conname                       as constraint_name,
+		       co.contype                       as constraint_type,
+		       array(
+		           select a.attname
+		           from unnest(co.conkey) with ordinality k(attnum, ord)
+		           join pg_attribute a on a.attrelid = t.oid and a.attnum = k.attnum
+		           order by k.ord
+		       )                                as columns,
+		       pg_get_constraintdef(co.oid)     as definition
+		from pg_constraint co
+		join pg_class t on t.oid = co.conrelid
+		join pg_namespace n on n.oid = t.relnamespace
+		where (n.nspname, t.relname) in (?)
+		order by 1, 2, 3
+	`
+
+	var constraints []constraint
+	err := s.db.NewRaw(query, bun.In(ts)).Scan(context.Background(), &constraints)
+	if err != nil {
+		return nil, fmt.Errorf("getting constraints info error: %w", err)
+	}
+
+	return constraints, nil
+}
+
+// Composites gets attributes of all user-defined composite types
+func (s *store) Composites() ([]compositeField, error) {
+	query := `
+		select n.nspname                                  as schema_name,
+		       t.typname                                  as type_name,
+		       a.attname                                  as field_name,
+		       at.typcategory = 'A'                       as is_array,
+		       case
+		       when at.typcategory = 'A'
+		       then greatest(a.attndims, 1)
+		       else 0
+		       end                                        as dims,
+		       ltrim(coalesce(et.typname, at.typname), '_') as type,
+		       coalesce(etn.nspname, atn.nspname)           as type_schema
+		from pg_type t
+		join pg_namespace n on n.oid = t.typnamespace
+		join pg_class c on c.oid = t.typrelid and c.relkind = 'c'
+		join pg_attribute a on a.attrelid = c.oid and a.attnum > 0 and not a.attisdropped
+		join pg_type at on at.oid = a.atttypid
+		join pg_namespace atn on atn.oid = at.typnamespace
+		left join pg_type et on et.oid = at.typelem and at.typcategory = 'A'
+		left join pg_namespace etn on etn.oid = et.typnamespace
+		where t.typtype = 'c'
+		  and n.nspname not in ('pg_catalog', 'information_schema')
+		order by n.nspname, t.typname, a.attnum
+	`
+
+	var fields []compositeField
+	err := s.db.NewRaw(query).Scan(context.Background(), &fields)
+	if err != nil {
+		return nil, fmt.Errorf("getting composite types info error: %w", err)
+	}
+
+	return fields, nil
+}
+
+// Domains gets all user-defined domains with their check constraints
+func (s *store) Domains() ([]domain, error) {
+	query := `
+		select n.nspname                       as schema_name,
+		       t.typname                       as domain_name,
+		       ltrim(bt.typname, '_')          as type,
+		       t.typnotnull                    as not_null,
+		       array_remove(array_agg(pg_get_constraintdef(co.oid) order by co.conname), null) as checks
+		from pg_type t
+		join pg_namespace n on n.oid = t.typnamespace
+		join pg_type bt on bt.oid = t.typbasetype
+		left join pg_constraint co on co.contypid = t.oid and co.contype = 'c'
+		where t.typtype = 'd'
+		  and n.nspname not in ('pg_catalog', 'information_schema')
+		group by 1, 2, 3, 4
+	`
+
+	var domains []domain
+	err := s.db.NewRaw(query).Scan(context.Background(), &domains)
+	if err != nil {
+		return nil, fmt.Errorf("getting domains info error: %w", err)
+	}
+
+	return domains, nil
+}
+
+// SampleJSON gets not null values of json column
+func (s *store) SampleJSON(schema, table, column string, limit int) ([]string, error) {
+	query := `select ?::text from ? where ? is not null limit ?`
+
+	var result []string
+	err := s.db.NewRaw(query, bun.Ident(column), bun.Ident(util.Join(schema, table)), bun.Ident(column), limit).
+		Scan(context.Background(), &result)
+	if err != nil {
+		return nil, fmt.Errorf("sampling json column %s error: %w", util.Join(table, column), err)
+	}
+
+	return result, nil
+}
+
 // Sort sorts table by schema and name (public tables always first)
 func Sort(tables []table) []table {
 	sort.Slice(tables, func(i, j int) bool {
@@ -365,3 +365,164 @@ func Test_store_Columns(t *testing.T) {
 		}
 	})
 }
+
+func Test_compositeField_Column(t *testing.T) {
+	f := compositeField{
+		Schema: "geo",
+		Name:   "location",
+		Field:  "label",
+		Type:   model.TypePGText,
+	}
+
+	want := model.NewColumn("label", model.TypePGText, true, false, false, 0, false, false, 0, nil, nil)
+	if got := f.Column(false, nil); !reflect.DeepEqual(got, want) {
+		t.Errorf("compositeField.Column() = %v, want %v", got, want)
+	}
+}
+
+func Test_domain_Domain(t *testing.T) {
+	d := domain{
+		Schema:  "geo",
+		Name:    "countryCode",
+		Type:    model.TypePGVarchar,
+		NotNull: true,
+		Checks:  []string{"CHECK (((VALUE)::text ~ '^[A-Z]{3}$'::text))"},
+	}
+
+	want := model.NewDomain("geo", "countryCode", model.TypePGVarchar, true, d.Checks)
+	if got := d.Domain(); !reflect.DeepEqual(got, want) {
+		t.Errorf("domain.Domain() = %v, want %v", got, want)
+	}
+}
+
+func Test_store_Composites(t *testing.T) {
+	store, err := prepareStore()
+	if err != nil {
+		t.Errorf("prepare Store error = %v", err)
+		return
+	}
+
+	t.Run("Should get all composite fields from test DB", func(t *testing.T) {
+		fields, err := store.Composites()
+		if err != nil {
+			t.Errorf("get composites error = %v", err)
+			return
+		}
+
+		if ln := len(fields); ln != 3 {
+			t.Errorf("len(Store.Composites()) = %v, want %v", ln, 3)
+			return
+		}
+	})
+}
+
+func Test_store_Domains(t *testing.T) {
+	store, err := prepareStore()
+	if err != nil {
+		t.Errorf("prepare Store error = %v", err)
+		return
+	}
+
+	t.Run("Should get all domains from test DB", func(t *testing.T) {
+		domains, err := store.Domains()
+		if err != nil {
+			t.Errorf("get domains error = %v", err)
+			return
+		}
+
+		if ln := len(domains); ln != 1 {
+			t.Errorf("len(Store.Domains()) = %v, want %v", ln, 1)
+			return
+		}
+
+		if ln := len(domains[0].Checks); ln != 1 {
+			t.Errorf("len(Store.Domains()[0].Checks) = %v, want %v", ln, 1)
+		}
+	})
+}
+
+func Test_store_Constraints(t *testing.T) {
+	store, err := prepareStore()
+	if err != nil {
+		t.Errorf("prepare Store error = %v", err)
+		return
+	}
+
+	t.Run("Should get users constraints from test DB", func(t *testing.T) {
+		constraints, err := store.Constraints([]table{{Schema: "public", Name: "users"}})
+		if err != nil {
+			t.Errorf("get constraints error = %v", err)
+			return
+		}
+
+		// foreign key, email check, unique email and primary key
+		if ln := len(constraints); ln != 4 {
+			t.Errorf("len(Store.Constraints()) = %v, want %v", ln, 4)
+			return
+		}
+
+		check := constraints[1]
+		if check.Type != model.ConstraintCheck || !reflect.DeepEqual(check.Columns, []string{"email"}) {
+			t.Errorf("Store.Constraints()[1] = %v, want check of email", check)
+		}
+	})
+}
+
+func Test_constraint_Constraint(t *testing.T) {
+	c := constraint{
+		Schema:     "public",
+		Table:      "users",
+		Name:       "users_email_check",
+		Type:       "c",
+		Columns:    []string{"email"},
+		Definition: "CHECK (((email)::text ~ '@'::text))",
+	}
+
+	want := model.NewConstraint("users_email_check", model.ConstraintCheck, []string{"email"}, "CHECK (((email)::text ~ '@'::text))")
+	if got := c.Constraint(); !reflect.DeepEqual(got, want) {
+		t.Errorf("constraint.Constraint() = %v, want %v", got, want)
+	}
+}
+
+func Test_index_Index(t *testing.T) {
+	i := index{
+		Schema:    "public",
+		Table:     "users",
+		Name:      "users_email_key",
+		Columns:   []string{"email"},
+		IsUnique:  true,
+		IsPrimary: false,
+		Method:    "btree",
+	}
+
+	want := model.NewIndex("users_email_key", []string{"email"}, true, false, "btree", false, false)
+	if got := i.Index(); !reflect.DeepEqual(got, want) {
+		t.Errorf("index.Index() = %v, want %v", got, want)
+	}
+}
+
+func Test_store_Indexes(t *testing.T) {
+	store, err := prepareStore()
+	if err != nil {
+		t.Errorf("prepare Store error = %v", err)
+		return
+	}
+
+	t.Run("Should get users indexes from test DB", func(t *testing.T) {
+		indexes, err := store.Indexes([]table{{Schema: "public", Name: "users"}})
+		if err != nil {
+			t.Errorf("get indexes error = %v", err)
+			return
+		}
+
+		// primary key, unique email, countryId and name
+		if ln := len(indexes); ln != 3 {
+			t.Errorf("len(Store.Indexes()) = %v, want %v", ln, 3)
+			return
+		}
+
+		if !indexes[0].IsPrimary {
+			t.Errorf("Store.Indexes()[0].IsPrimary = %v, want %v", indexes[0].IsPrimary, true)
+		}
+	})
+}
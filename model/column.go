@@ -1,7 +1,7 @@
 package model
 
 import (
-	"github.com/ant31/bungen/util"
+	"strings"
 )
 
 type columnRelWrap struct {
@@ -34,6 +34,28 @@ type Column struct {
 
 	MaxLen int
 	Values []string
+	// Precision and Scale are set for numeric(precision, scale) columns
+	Precision int
+	Scale     int
+
+	// Default is a column default expression, e.g. nextval('users_id_seq'::regclass)
+	Default string
+	// IsIdentity is set for GENERATED ... AS IDENTITY columns
+	IsIdentity bool
+	// IsGenerated is set for GENERATED ALWAYS AS (...) STORED columns
+	IsGenerated bool
+
+	// Composite is set if column type is user-defined composite
+	Composite *Composite
+	// Domain is set if column type is user-defined domain
+	Domain *Domain
+
+	// Description is a column comment
+	Description string
+	// JSONType is set if go structs were generated for json column
+	JSONType *JSONType
+	// Embed is a name of struct shared by several entities, column is its field if set
+	Embed string
 }
 
 // NewColumn creates Column from Postgres info
@@ -53,7 +75,7 @@ func NewColumn(pgName string, pgType string, nullable, sqlNulls, array bool, dim
 		IsFK:       fk,
 		MaxLen:     len,
 		Values:     values,
-		GoName:     util.ColumnName(pgName),
+		GoName:     naming.ColumnName(pgName),
 	}
 
 	if customTypes == nil {
@@ -67,6 +89,8 @@ func NewColumn(pgName string, pgType string, nullable, sqlNulls, array bool, dim
 	}
 
 	switch {
+	case column.IsArray && customTypes.Has(pgType):
+		column.Type = goSliceOf(column.GoType, dims)
 	case column.IsArray:
 		column.Type, err = GoSlice(pgType, dims)
 	case column.Nullable:
@@ -86,6 +110,16 @@ func NewColumn(pgName string, pgType string, nullable, sqlNulls, array bool, dim
 	return column
 }
 
+// HasDefault checks if column value can be set by database on insert
+func (c Column) HasDefault() bool {
+	return c.Default != "" || c.IsIdentity
+}
+
+// IsSerial checks if column is filled from sequence: serial or identity
+func (c Column) IsSerial() bool {
+	return c.IsIdentity || strings.HasPrefix(c.Default, "nextval(")
+}
+
 // AddRelation adds relation to column. Should be used if FK
 func (c *Column) AddRelation(relation *Relation, relPK string) {
 	c.Relation = &columnRelWrap{
@@ -130,3 +130,91 @@ func TestColumn_GoType(t *testing.T) {
 		})
 	}
 }
+
+func TestColumn_CustomType(t *testing.T) {
+	customTypes := CustomTypeMapping{}
+	customTypes.Add(TypePGCidr, "Cidr", "")
+
+	tests := []struct {
+		name     string
+		nullable bool
+		array    bool
+		dims     int
+		want     string
+	}{
+		{
+			name: "Should generate custom type",
+			want: "Cidr",
+		},
+		{
+			name:     "Should generate nullable custom type",
+			nullable: true,
+			want:     "*Cidr",
+		},
+		{
+			name:  "Should generate custom type array",
+			array: true,
+			dims:  0,
+			want:  "[]Cidr",
+		},
+		{
+			name:     "Should generate custom type 2-dimensional array",
+			nullable: true,
+			array:    true,
+			dims:     2,
+			want:     "[][]Cidr",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := NewColumn("test", TypePGCidr, tt.nullable, false, tt.array, tt.dims, false, false, 0, []string{}, customTypes)
+			if got := c.Type; got != tt.want {
+				t.Errorf("Column.Type = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestColumn_IsSerial(t *testing.T) {
+	tests := []struct {
+		name        string
+		def         string
+		identity    bool
+		wantSerial  bool
+		wantDefault bool
+	}{
+		{
+			name:        "Should detect serial",
+			def:         "nextval('users_id_seq'::regclass)",
+			wantSerial:  true,
+			wantDefault: true,
+		},
+		{
+			name:        "Should detect identity",
+			identity:    true,
+			wantSerial:  true,
+			wantDefault: true,
+		},
+		{
+			name:        "Should detect default",
+			def:         "now()",
+			wantDefault: true,
+		},
+		{
+			name: "Should detect no default",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := NewColumn("id", TypePGInt8, false, false, false, 0, true, false, 0, []string{}, nil)
+			c.Default = tt.def
+			c.IsIdentity = tt.identity
+			if got := c.IsSerial(); got != tt.wantSerial {
+				t.Errorf("Column.IsSerial() = %v, want %v", got, tt.wantSerial)
+			}
+			if got := c.HasDefault(); got != tt.wantDefault {
+				t.Errorf("Column.HasDefault() = %v, want %v", got, tt.wantDefault)
+			}
+		})
+	}
+}
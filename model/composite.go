@@ -0,0 +1,92 @@
+package model
+
+import (
+	"github.com/ant31/bungen/util"
+)
+
+// Composite stores information about user-defined composite type
+type Composite struct {
+	GoName     string
+	PGName     string
+	PGSchema   string
+	PGFullName string
+
+	Fields []Column
+
+	Imports []string
+
+	// helper indexes
+	colIndex util.Index
+	impIndex map[string]struct{}
+}
+
+// NewComposite creates new Composite from Postgres info
+func NewComposite(schema, pgName string) Composite {
+	goName := identifier(pgName)
+	if schema != util.PublicSchema {
+		goName = identifier(schema) + goName
+	}
+
+	composite := Composite{
+		GoName:     goName,
+		PGName:     pgName,
+		PGSchema:   schema,
+		PGFullName: util.JoinF(schema, pgName),
+
+		Fields:   []Column{},
+		colIndex: util.NewIndex(),
+
+		Imports:  []string{},
+		impIndex: map[string]struct{}{},
+	}
+
+	// methods generated for composite
+	composite.colIndex.Add("Scan")
+	composite.colIndex.Add("Value")
+
+	return composite
+}
+
+// AddField adds attribute to composite
+func (c *Composite) AddField(column Column) {
+	if !c.colIndex.Available(column.GoName) {
+		column.GoName = c.colIndex.GetNext(column.GoName)
+	}
+	c.colIndex.Add(column.GoName)
+
+	c.Fields = append(c.Fields, column)
+
+	if imp := column.Import; imp != "" {
+		if _, ok := c.impIndex[imp]; !ok {
+			c.impIndex[imp] = struct{}{}
+			c.Imports = append(c.Imports, imp)
+		}
+	}
+}
+
+// Domain stores information about user-defined domain
+type Domain struct {
+	PGName     string
+	PGSchema   string
+	PGFullName string
+
+	// PGType is a base type of domain
+	PGType  string
+	NotNull bool
+
+	// Checks stores CHECK constraints definitions, e.g. CHECK ((VALUE > 0))
+	Checks []string
+}
+
+// NewDomain creates new Domain from Postgres info
+func NewDomain(schema, pgName, pgType string, notNull bool, checks []string) Domain {
+	return Domain{
+		PGName:     pgName,
+		PGSchema:   schema,
+		PGFullName: util.JoinF(schema, pgName),
+
+		PGType:  pgType,
+		NotNull: notNull,
+		Checks:  checks,
+	}
+}
@@ -0,0 +1,60 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/ant31/bungen/util"
+)
+
+func TestComposite_GoName(t *testing.T) {
+	tests := []struct {
+		name   string
+		schema string
+		pgName string
+		want   string
+	}{
+		{
+			name:   "Should generate from simple word",
+			schema: util.PublicSchema,
+			pgName: "address",
+			want:   "Address",
+		},
+		{
+			name:   "Should generate from underscored",
+			schema: util.PublicSchema,
+			pgName: "postal_address",
+			want:   "PostalAddress",
+		},
+		{
+			name:   "Should generate with schema",
+			schema: "geo",
+			pgName: "location",
+			want:   "GeoLocation",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NewComposite(tt.schema, tt.pgName).GoName; got != tt.want {
+				t.Errorf("Composite.GoName = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestComposite_AddField(t *testing.T) {
+	composite := NewComposite(util.PublicSchema, "address")
+
+	composite.AddField(NewColumn("name", TypePGText, true, false, false, 0, false, false, 0, nil, nil))
+	composite.AddField(NewColumn("Name", TypePGText, true, false, false, 0, false, false, 0, nil, nil))
+	composite.AddField(NewColumn("since", TypePGTimestamp, true, false, false, 0, false, false, 0, nil, nil))
+
+	if len(composite.Fields) != 3 {
+		t.Errorf("Composite.Fields = %v, want %v", len(composite.Fields), 3)
+	}
+	if composite.Fields[1].GoName != "Name1" {
+		t.Errorf("Composite.Fields[1].GoName = %v, want %v", composite.Fields[1].GoName, "Name1")
+	}
+	if len(composite.Imports) != 1 {
+		t.Errorf("Composite.Imports = %v, want %v", len(composite.Imports), 1)
+	}
+}
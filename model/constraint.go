@@ -0,0 +1,36 @@
+package model
+
+// constraint types as in pg_constraint.contype
+const (
+	ConstraintCheck      = "c"
+	ConstraintForeignKey = "f"
+	ConstraintPrimaryKey = "p"
+	ConstraintUnique     = "u"
+	ConstraintExclusion  = "x"
+)
+
+// Constraint stores information about table constraint
+type Constraint struct {
+	Name string
+	// Type is a constraint type, e.g. ConstraintCheck
+	Type string
+	// Columns are constrained columns in constraint order
+	Columns []string
+	// Definition is a constraint definition, e.g. CHECK ((price >= (0)::numeric))
+	Definition string
+}
+
+// NewConstraint creates Constraint from Postgres info
+func NewConstraint(name, typ string, columns []string, definition string) Constraint {
+	return Constraint{
+		Name:       name,
+		Type:       typ,
+		Columns:    columns,
+		Definition: definition,
+	}
+}
+
+// IsCheck checks if constraint is a CHECK constraint
+func (c Constraint) IsCheck() bool {
+	return c.Type == ConstraintCheck
+}
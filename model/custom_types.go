@@ -90,14 +90,30 @@ func parseCustomType(raw string) (pgType, goType, goImport string, err error) {
 	}
 
 	pgType = split[0]
+	goType, goImport, err = ParseGoType(split[1])
 
-	ind := strings.LastIndexByte(split[1], '.')
+	return
+}
+
+// ParseGoType parses go type with optional import path
+// format: [*|[]]<go_import>.<go_type>, e.g. *github.com/google/uuid.UUID
+func ParseGoType(raw string) (goType, goImport string, err error) {
+	modifiers := ""
+	for strings.HasPrefix(raw, "*") || strings.HasPrefix(raw, "[]") {
+		if raw[0] == '*' {
+			modifiers, raw = modifiers+"*", raw[1:]
+		} else {
+			modifiers, raw = modifiers+"[]", raw[2:]
+		}
+	}
+
+	ind := strings.LastIndexByte(raw, '.')
 	if ind == -1 {
-		goType = split[1]
+		goType = modifiers + raw
 		return
 	}
 
-	goImport, goType = split[1][:ind], split[1][ind:]
+	goImport, goType = raw[:ind], raw[ind:]
 
 	if goType == "." || goImport == "" {
 		err = fmt.Errorf("custom type mapping has invalid format (missing type or import)")
@@ -111,7 +127,7 @@ func parseCustomType(raw string) (pgType, goType, goImport string, err error) {
 
 	ind = strings.LastIndexByte(goImport, '/')
 	if ind == -1 {
-		goType = fmt.Sprintf("%s%s", goImport, goType)
+		goType = fmt.Sprintf("%s%s%s", modifiers, goImport, goType)
 		return
 	}
 
@@ -120,7 +136,7 @@ func parseCustomType(raw string) (pgType, goType, goImport string, err error) {
 		base = path.Base(path.Dir(goImport))
 	}
 
-	goType = fmt.Sprintf("%s%s", base, goType)
+	goType = fmt.Sprintf("%s%s%s", modifiers, base, goType)
 
 	return
 }
@@ -131,3 +131,59 @@ func TestParseCustomTypes(t *testing.T) {
 		})
 	}
 }
+
+func TestParseGoType(t *testing.T) {
+	tests := []struct {
+		name         string
+		raw          string
+		wantGoType   string
+		wantGoImport string
+		wantErr      bool
+	}{
+		{
+			name:       "Should parse builtin type",
+			raw:        "map[string]interface{}",
+			wantGoType: "map[string]interface{}",
+		},
+		{
+			name:         "Should parse external import",
+			raw:          "github.com/acme/money.Amount",
+			wantGoType:   "money.Amount",
+			wantGoImport: "github.com/acme/money",
+		},
+		{
+			name:         "Should parse pointer to external import",
+			raw:          "*github.com/acme/money.Amount",
+			wantGoType:   "*money.Amount",
+			wantGoImport: "github.com/acme/money",
+		},
+		{
+			name:         "Should parse slice of std import",
+			raw:          "[]encoding/json.RawMessage",
+			wantGoType:   "[]json.RawMessage",
+			wantGoImport: "encoding/json",
+		},
+		{
+			name:    "Should error on missing type",
+			raw:     "github.com/acme/money.",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotGoType, gotGoImport, err := ParseGoType(tt.raw)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ParseGoType() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if gotGoType != tt.wantGoType {
+				t.Errorf("ParseGoType() gotGoType = %v, want %v", gotGoType, tt.wantGoType)
+			}
+			if gotGoImport != tt.wantGoImport {
+				t.Errorf("ParseGoType() gotGoImport = %v, want %v", gotGoImport, tt.wantGoImport)
+			}
+		})
+	}
+}
@@ -16,6 +16,9 @@ type Entity struct {
 
 	Columns   []Column
 	Relations []Relation
+	Indexes   []Index
+	// Constraints are table constraints, including ones backed by indexes
+	Constraints []Constraint
 
 	Imports []string
 
@@ -26,31 +29,26 @@ type Entity struct {
 
 // NewEntity creates new Entity from Postgres info
 func NewEntity(schema, pgName string, columns []Column, relations []Relation) Entity {
-	goName := util.EntityName(pgName)
-	if schema != util.PublicSchema {
-		goName = util.CamelCased(schema) + goName
-	}
-
-	goNamePlural := util.CamelCased(util.Sanitize(pgName))
-	if schema != util.PublicSchema {
-		goNamePlural = util.CamelCased(schema) + goNamePlural
-	}
-
 	entity := Entity{
-		GoName:       goName,
-		GoNamePlural: goNamePlural,
+		GoName:       naming.EntityName(schema, pgName),
+		GoNamePlural: naming.EntityNamePlural(schema, pgName),
 		PGName:       pgName,
 		PGSchema:     schema,
 		PGFullName:   util.JoinF(schema, pgName),
 
 		Columns:   []Column{},
 		Relations: []Relation{},
+		Indexes:   []Index{},
 		colIndex:  util.NewIndex(),
 
 		Imports:  []string{},
 		impIndex: map[string]struct{}{},
 	}
 
+	for _, name := range ReservedFieldNames {
+		entity.colIndex.Add(name)
+	}
+
 	if columns != nil {
 		for _, col := range columns {
 			entity.AddColumn(col)
@@ -129,3 +127,35 @@ func (e *Entity) GetPKs() []Column {
 	}
 	return res
 }
+
+// AddIndex adds index to entity
+func (e *Entity) AddIndex(index Index) {
+	e.Indexes = append(e.Indexes, index)
+}
+
+// UniqueIndexes returns plain unique indexes except primary key
+func (e *Entity) UniqueIndexes() []Index {
+	var res []Index
+	for _, index := range e.Indexes {
+		if index.IsUnique && !index.IsPrimary && index.IsPlain() {
+			res = append(res, index)
+		}
+	}
+	return res
+}
+
+// AddConstraint adds constraint to entity
+func (e *Entity) AddConstraint(constraint Constraint) {
+	e.Constraints = append(e.Constraints, constraint)
+}
+
+// Checks returns CHECK constraints
+func (e *Entity) Checks() []Constraint {
+	var res []Constraint
+	for _, constraint := range e.Constraints {
+		if constraint.IsCheck() {
+			res = append(res, constraint)
+		}
+	}
+	return res
+}
@@ -174,3 +174,17 @@ func TestEntity_HasMultiplePKs(t *testing.T) {
 		})
 	})
 }
+
+func TestEntity_UniqueIndexes(t *testing.T) {
+	entity := NewEntity(util.PublicSchema, "users", nil, nil)
+	entity.AddIndex(NewIndex("users_pkey", []string{"id"}, true, true, "btree", false, false))
+	entity.AddIndex(NewIndex("users_email_key", []string{"email"}, true, false, "btree", false, false))
+	entity.AddIndex(NewIndex("users_login_key", []string{"login"}, true, false, "btree", true, false))
+	entity.AddIndex(NewIndex("users_lower_email_key", nil, true, false, "btree", false, true))
+	entity.AddIndex(NewIndex("users_name_idx", []string{"name"}, false, false, "btree", false, false))
+
+	got := entity.UniqueIndexes()
+	if len(got) != 1 || got[0].Name != "users_email_key" {
+		t.Errorf("Entity.UniqueIndexes() = %v, want [users_email_key]", got)
+	}
+}
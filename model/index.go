@@ -0,0 +1,41 @@
+package model
+
+// Index stores information about table index, unique constraints are backed by unique indexes
+type Index struct {
+	Name string
+	// Columns are key columns in index order, INCLUDE columns are not listed
+	Columns []string
+
+	IsUnique  bool
+	IsPrimary bool
+	// Method is an access method, e.g. btree, hash, gin
+	Method string
+
+	// IsPartial is set for indexes with WHERE predicate
+	IsPartial bool
+	// HasExpressions is set if any key is an expression, not a column
+	HasExpressions bool
+}
+
+// NewIndex creates Index from Postgres info
+func NewIndex(name string, columns []string, unique, primary bool, method string, partial, expressions bool) Index {
+	return Index{
+		Name:           name,
+		Columns:        columns,
+		IsUnique:       unique,
+		IsPrimary:      primary,
+		Method:         method,
+		IsPartial:      partial,
+		HasExpressions: expressions,
+	}
+}
+
+// IsPlain checks if index covers plain columns for all rows
+func (i Index) IsPlain() bool {
+	return !i.IsPartial && !i.HasExpressions && len(i.Columns) > 0
+}
+
+// IsBtree checks if index can be used for equality and prefix lookups
+func (i Index) IsBtree() bool {
+	return i.Method == "btree"
+}
@@ -0,0 +1,420 @@
+package model
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/ant31/bungen/util"
+)
+
+// JSONType stores go type generated for json column with all structs it requires
+type JSONType struct {
+	// Type is go type of column, e.g. UserMeta or []UserTag
+	Type    string
+	Structs []JSONStruct
+	Imports []string
+}
+
+// JSONStruct stores go struct generated for json object
+type JSONStruct struct {
+	GoName string
+	Fields []JSONField
+}
+
+// JSONField stores field of go struct generated for json object
+type JSONField struct {
+	GoName   string
+	JSONName string
+	Type     string
+	Tag      string
+}
+
+// IsStruct checks if json type is a struct (not a slice, map or scalar)
+func (t *JSONType) IsStruct() bool {
+	for _, s := range t.Structs {
+		if s.GoName == t.Type {
+			return true
+		}
+	}
+	return false
+}
+
+// jsonBuilder collects structs while walking through json schema or samples
+type jsonBuilder struct {
+	structs []JSONStruct
+	names   util.Index
+	imports util.Set
+	// reserved are final names of structs referenced before they are added, e.g. recursive definitions
+	reserved map[string]struct{}
+}
+
+func newJSONBuilder() *jsonBuilder {
+	return &jsonBuilder{
+		names:    util.NewIndex(),
+		imports:  util.NewSet(),
+		reserved: map[string]struct{}{},
+	}
+}
+
+// reserve gets final name of struct which is added later with addStruct
+func (b *jsonBuilder) reserve(name string) string {
+	name = b.names.GetNext(name)
+	b.names.Add(name)
+	b.reserved[name] = struct{}{}
+
+	return name
+}
+
+func (b *jsonBuilder) addStruct(name string, fields []JSONField) string {
+	if _, ok := b.reserved[name]; ok {
+		delete(b.reserved, name)
+	} else {
+		name = b.names.GetNext(name)
+		b.names.Add(name)
+	}
+
+	index := util.NewIndex()
+	for i := range fields {
+		fields[i].GoName = index.GetNext(fields[i].GoName)
+		index.Add(fields[i].GoName)
+	}
+
+	b.structs = append(b.structs, JSONStruct{GoName: name, Fields: fields})
+	return name
+}
+
+func (b *jsonBuilder) result(typ string) *JSONType {
+	return &JSONType{
+		Type:    typ,
+		Structs: b.structs,
+		Imports: b.imports.Elements(),
+	}
+}
+
+func newJSONField(name, typ string, required bool) JSONField {
+	tag := name
+	if !required {
+		tag += ",omitempty"
+	}
+
+	goName := identifier(name)
+	if goName == "" {
+		goName = "Field"
+	}
+
+	return JSONField{
+		GoName:   goName,
+		JSONName: name,
+		Type:     typ,
+		Tag:      fmt.Sprintf("`json:%q`", tag),
+	}
+}
+
+// jsonSchema is a subset of JSON Schema used to generate go types
+type jsonSchema struct {
+	Type                 interface{}            `json:"type"`
+	Format               string                 `json:"format"`
+	Ref                  string                 `json:"$ref"`
+	Properties           map[string]*jsonSchema `json:"properties"`
+	Required             []string               `json:"required"`
+	Items                *jsonSchema            `json:"items"`
+	AdditionalProperties interface{}            `json:"additionalProperties"`
+	Definitions          map[string]*jsonSchema `json:"definitions"`
+	Defs                 map[string]*jsonSchema `json:"$defs"`
+}
+
+// types returns schema types without null and whether null is allowed
+func (s *jsonSchema) types() ([]string, bool) {
+	var types []string
+	switch t := s.Type.(type) {
+	case string:
+		types = []string{t}
+	case []interface{}:
+		for _, v := range t {
+			if str, ok := v.(string); ok {
+				types = append(types, str)
+			}
+		}
+	}
+
+	nullable := false
+	result := types[:0]
+	for _, t := range types {
+		if t == "null" {
+			nullable = true
+			continue
+		}
+		result = append(result, t)
+	}
+
+	return result, nullable
+}
+
+// NewJSONTypeFromSchema generates go types from JSON Schema
+func NewJSONTypeFromSchema(name string, raw []byte) (*JSONType, error) {
+	var root jsonSchema
+	if err := json.Unmarshal(raw, &root); err != nil {
+		return nil, fmt.Errorf("parsing json schema error: %w", err)
+	}
+
+	b := newJSONBuilder()
+	refs := map[string]string{}
+	rootName := name
+
+	var walk func(name string, s *jsonSchema) (string, error)
+	walk = func(name string, s *jsonSchema) (string, error) {
+		if s == nil {
+			return TypeInterface, nil
+		}
+
+		if s.Ref != "" {
+			if typ, ok := refs[s.Ref]; ok {
+				return typ, nil
+			}
+
+			def, defName, err := root.resolve(s.Ref)
+			if err != nil {
+				return "", err
+			}
+
+			// placeholder for recursive definitions, name is reserved to be the same in placeholder and struct
+			defName = b.reserve(rootName + naming.EntityName(util.PublicSchema, defName))
+			refs[s.Ref] = "*" + defName
+			typ, err := walk(defName, def)
+			if err != nil {
+				return "", err
+			}
+			refs[s.Ref] = typ
+
+			return typ, nil
+		}
+
+		types, nullable := s.types()
+		if len(types) == 0 && s.Properties != nil {
+			types = []string{"object"}
+		}
+		if len(types) != 1 {
+			return TypeInterface, nil
+		}
+
+		var typ string
+		switch types[0] {
+		case "string":
+			typ = TypeString
+			if s.Format == "date-time" {
+				typ = TypeTime
+				b.imports.Add("time")
+			}
+		case "integer":
+			typ = TypeInt64
+		case "number":
+			typ = TypeFloat64
+		case "boolean":
+			typ = TypeBool
+		case "array":
+			item, err := walk(util.Singular(name), s.Items)
+			if err != nil {
+				return "", err
+			}
+			return "[]" + item, nil
+		case "object":
+			if len(s.Properties) == 0 {
+				return TypeMapInterface, nil
+			}
+
+			required := map[string]bool{}
+			for _, r := range s.Required {
+				required[r] = true
+			}
+
+			keys := make([]string, 0, len(s.Properties))
+			for key := range s.Properties {
+				keys = append(keys, key)
+			}
+			sort.Strings(keys)
+
+			fields := make([]JSONField, 0, len(keys))
+			for _, key := range keys {
+				fieldType, err := walk(name+identifier(key), s.Properties[key])
+				if err != nil {
+					return "", err
+				}
+				if !required[key] {
+					fieldType = jsonOptional(fieldType)
+				}
+				fields = append(fields, newJSONField(key, fieldType, required[key]))
+			}
+
+			return b.addStruct(name, fields), nil
+		default:
+			return TypeInterface, nil
+		}
+
+		if nullable {
+			return jsonOptional(typ), nil
+		}
+		return typ, nil
+	}
+
+	typ, err := walk(name, &root)
+	if err != nil {
+		return nil, err
+	}
+
+	return b.result(typ), nil
+}
+
+// resolve finds local definition by reference, e.g. #/definitions/address
+func (s *jsonSchema) resolve(ref string) (*jsonSchema, string, error) {
+	for prefix, defs := range map[string]map[string]*jsonSchema{
+		"#/definitions/": s.Definitions,
+		"#/$defs/":       s.Defs,
+	} {
+		if strings.HasPrefix(ref, prefix) {
+			name := strings.TrimPrefix(ref, prefix)
+			if def, ok := defs[name]; ok {
+				return def, name, nil
+			}
+		}
+	}
+
+	return nil, "", fmt.Errorf("unsupported json schema reference %s", ref)
+}
+
+// jsonOptional makes type optional: pointer for scalars and structs
+func jsonOptional(typ string) string {
+	if strings.HasPrefix(typ, "*") || strings.HasPrefix(typ, "[]") || strings.HasPrefix(typ, "map[") || typ == TypeInterface {
+		return typ
+	}
+	return "*" + typ
+}
+
+// jsonSample stores merged information about sampled json values
+type jsonSample struct {
+	count   int
+	null    bool
+	kinds   map[string]struct{}
+	integer bool
+
+	// objects
+	keys   []string
+	fields map[string]*jsonSample
+
+	// arrays
+	items *jsonSample
+}
+
+func newJSONSample() *jsonSample {
+	return &jsonSample{
+		kinds:   map[string]struct{}{},
+		integer: true,
+		fields:  map[string]*jsonSample{},
+	}
+}
+
+func (s *jsonSample) add(value interface{}) {
+	s.count++
+
+	switch v := value.(type) {
+	case nil:
+		s.null = true
+	case string:
+		s.kinds["string"] = struct{}{}
+	case bool:
+		s.kinds["boolean"] = struct{}{}
+	case json.Number:
+		s.kinds["number"] = struct{}{}
+		if _, err := v.Int64(); err != nil {
+			s.integer = false
+		}
+	case []interface{}:
+		s.kinds["array"] = struct{}{}
+		if s.items == nil {
+			s.items = newJSONSample()
+		}
+		for _, item := range v {
+			s.items.add(item)
+		}
+	case map[string]interface{}:
+		s.kinds["object"] = struct{}{}
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			field, ok := s.fields[key]
+			if !ok {
+				field = newJSONSample()
+				s.fields[key] = field
+				s.keys = append(s.keys, key)
+			}
+			field.add(v[key])
+		}
+	}
+}
+
+// NewJSONTypeFromSamples infers go types from sampled json values
+func NewJSONTypeFromSamples(name string, samples [][]byte) (*JSONType, error) {
+	root := newJSONSample()
+	for _, sample := range samples {
+		decoder := json.NewDecoder(strings.NewReader(string(sample)))
+		decoder.UseNumber()
+
+		var value interface{}
+		if err := decoder.Decode(&value); err != nil {
+			return nil, fmt.Errorf("parsing json sample error: %w", err)
+		}
+		root.add(value)
+	}
+
+	b := newJSONBuilder()
+
+	var walk func(name string, s *jsonSample) string
+	walk = func(name string, s *jsonSample) string {
+		if s == nil || len(s.kinds) != 1 {
+			return TypeInterface
+		}
+
+		var typ string
+		for kind := range s.kinds {
+			switch kind {
+			case "string":
+				typ = TypeString
+			case "boolean":
+				typ = TypeBool
+			case "number":
+				typ = TypeFloat64
+				if s.integer {
+					typ = TypeInt64
+				}
+			case "array":
+				return "[]" + walk(util.Singular(name), s.items)
+			case "object":
+				if len(s.keys) == 0 {
+					return TypeMapInterface
+				}
+
+				fields := make([]JSONField, 0, len(s.keys))
+				for _, key := range s.keys {
+					field := s.fields[key]
+					required := field.count == s.count && !field.null
+
+					fieldType := walk(name+identifier(key), field)
+					if !required {
+						fieldType = jsonOptional(fieldType)
+					}
+					fields = append(fields, newJSONField(key, fieldType, required))
+				}
+
+				typ = b.addStruct(name, fields)
+			}
+		}
+
+		return typ
+	}
+
+	return b.result(walk(name, root)), nil
+}
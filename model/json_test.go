@@ -0,0 +1,202 @@
+package model
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestNewJSONTypeFromSchema(t *testing.T) {
+	tests := []struct {
+		name        string
+		schema      string
+		wantType    string
+		wantStructs []JSONStruct
+		wantImports []string
+		wantErr     bool
+	}{
+		{
+			name: "Should generate struct with required and optional fields",
+			schema: `{
+				"type": "object",
+				"required": ["name"],
+				"properties": {
+					"name": {"type": "string"},
+					"age": {"type": "integer"},
+					"born_at": {"type": "string", "format": "date-time"}
+				}
+			}`,
+			wantType: "UserTags",
+			wantStructs: []JSONStruct{
+				{GoName: "UserTags", Fields: []JSONField{
+					{GoName: "Age", JSONName: "age", Type: "*int64", Tag: "`json:\"age,omitempty\"`"},
+					{GoName: "BornAt", JSONName: "born_at", Type: "*time.Time", Tag: "`json:\"born_at,omitempty\"`"},
+					{GoName: "Name", JSONName: "name", Type: "string", Tag: "`json:\"name\"`"},
+				}},
+			},
+			wantImports: []string{"time"},
+		},
+		{
+			name: "Should generate nested structs and arrays",
+			schema: `{
+				"type": "array",
+				"items": {
+					"type": "object",
+					"required": ["address"],
+					"properties": {
+						"address": {"$ref": "#/definitions/address"},
+						"tags": {"type": "array", "items": {"type": "string"}}
+					}
+				},
+				"definitions": {
+					"address": {
+						"type": "object",
+						"required": ["city"],
+						"properties": {"city": {"type": ["string", "null"]}}
+					}
+				}
+			}`,
+			wantType: "[]UserTag",
+			wantStructs: []JSONStruct{
+				{GoName: "UserTagsAddress", Fields: []JSONField{
+					{GoName: "City", JSONName: "city", Type: "*string", Tag: "`json:\"city\"`"},
+				}},
+				{GoName: "UserTag", Fields: []JSONField{
+					{GoName: "Address", JSONName: "address", Type: "UserTagsAddress", Tag: "`json:\"address\"`"},
+					{GoName: "Tags", JSONName: "tags", Type: "[]string", Tag: "`json:\"tags,omitempty\"`"},
+				}},
+			},
+			wantImports: []string{},
+		},
+		{
+			name: "Should keep name of recursive definition taken by other struct",
+			schema: `{
+				"type": "object",
+				"required": ["node", "tree"],
+				"properties": {
+					"node": {"type": "object", "required": ["x"], "properties": {"x": {"type": "string"}}},
+					"tree": {"$ref": "#/definitions/node"}
+				},
+				"definitions": {
+					"node": {"type": "object", "properties": {"child": {"$ref": "#/definitions/node"}}}
+				}
+			}`,
+			wantType: "UserTags",
+			wantStructs: []JSONStruct{
+				{GoName: "UserTagsNode", Fields: []JSONField{
+					{GoName: "X", JSONName: "x", Type: "string", Tag: "`json:\"x\"`"},
+				}},
+				{GoName: "UserTagsNode1", Fields: []JSONField{
+					{GoName: "Child", JSONName: "child", Type: "*UserTagsNode1", Tag: "`json:\"child,omitempty\"`"},
+				}},
+				{GoName: "UserTags", Fields: []JSONField{
+					{GoName: "Node", JSONName: "node", Type: "UserTagsNode", Tag: "`json:\"node\"`"},
+					{GoName: "Tree", JSONName: "tree", Type: "UserTagsNode1", Tag: "`json:\"tree\"`"},
+				}},
+			},
+			wantImports: []string{},
+		},
+		{
+			name:        "Should generate map for object without properties",
+			schema:      `{"type": "object"}`,
+			wantType:    TypeMapInterface,
+			wantImports: []string{},
+		},
+		{
+			name:    "Should error on unknown reference",
+			schema:  `{"$ref": "#/definitions/unknown"}`,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewJSONTypeFromSchema("UserTags", []byte(tt.schema))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NewJSONTypeFromSchema() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if got.Type != tt.wantType {
+				t.Errorf("NewJSONTypeFromSchema().Type = %v, want %v", got.Type, tt.wantType)
+			}
+			if !reflect.DeepEqual(got.Structs, tt.wantStructs) {
+				t.Errorf("NewJSONTypeFromSchema().Structs = %v, want %v", got.Structs, tt.wantStructs)
+			}
+			if !reflect.DeepEqual(got.Imports, tt.wantImports) {
+				t.Errorf("NewJSONTypeFromSchema().Imports = %v, want %v", got.Imports, tt.wantImports)
+			}
+		})
+	}
+}
+
+func TestNewJSONTypeFromSamples(t *testing.T) {
+	tests := []struct {
+		name        string
+		samples     []string
+		wantType    string
+		wantStructs []JSONStruct
+		wantErr     bool
+	}{
+		{
+			name: "Should infer struct from objects",
+			samples: []string{
+				`{"name": "john", "age": 30, "score": 1.5}`,
+				`{"name": "jane", "age": 25, "score": 2, "nick": null}`,
+			},
+			wantType: "UserTags",
+			wantStructs: []JSONStruct{
+				{GoName: "UserTags", Fields: []JSONField{
+					{GoName: "Age", JSONName: "age", Type: "int64", Tag: "`json:\"age\"`"},
+					{GoName: "Name", JSONName: "name", Type: "string", Tag: "`json:\"name\"`"},
+					{GoName: "Score", JSONName: "score", Type: "float64", Tag: "`json:\"score\"`"},
+					{GoName: "Nick", JSONName: "nick", Type: "interface{}", Tag: "`json:\"nick,omitempty\"`"},
+				}},
+			},
+		},
+		{
+			name: "Should infer slice of structs",
+			samples: []string{
+				`[{"id": 1, "labels": ["a"]}, {"id": 2}]`,
+			},
+			wantType: "[]UserTag",
+			wantStructs: []JSONStruct{
+				{GoName: "UserTag", Fields: []JSONField{
+					{GoName: "ID", JSONName: "id", Type: "int64", Tag: "`json:\"id\"`"},
+					{GoName: "Labels", JSONName: "labels", Type: "[]string", Tag: "`json:\"labels,omitempty\"`"},
+				}},
+			},
+		},
+		{
+			name:     "Should fallback to interface on mixed types",
+			samples:  []string{`1`, `"a"`},
+			wantType: TypeInterface,
+		},
+		{
+			name:    "Should error on invalid json",
+			samples: []string{`{`},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			samples := make([][]byte, len(tt.samples))
+			for i, s := range tt.samples {
+				samples[i] = []byte(s)
+			}
+
+			got, err := NewJSONTypeFromSamples("UserTags", samples)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NewJSONTypeFromSamples() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if got.Type != tt.wantType {
+				t.Errorf("NewJSONTypeFromSamples().Type = %v, want %v", got.Type, tt.wantType)
+			}
+			if !reflect.DeepEqual(got.Structs, tt.wantStructs) {
+				t.Errorf("NewJSONTypeFromSamples().Structs = %v, want %v", got.Structs, tt.wantStructs)
+			}
+		})
+	}
+}
@@ -0,0 +1,312 @@
+package model
+
+import (
+	"fmt"
+	"go/token"
+	"strings"
+
+	"github.com/fatih/camelcase"
+	"github.com/jinzhu/inflection"
+
+	"github.com/ant31/bungen/util"
+)
+
+// NamingStrategy converts postgres names to go identifiers
+type NamingStrategy interface {
+	// EntityName gets struct name for table, e.g. users -> User
+	EntityName(schema, table string) string
+	// EntityNamePlural gets plural name for table, e.g. users -> Users
+	EntityNamePlural(schema, table string) string
+	// ColumnName gets struct field name for column, e.g. api_key -> APIKey
+	ColumnName(column string) string
+}
+
+// DefaultInitialisms is a list of common initialisms from golint
+var DefaultInitialisms = []string{
+	"ACL", "AI", "API", "ASCII", "CPU", "CSS", "DNS", "EOF", "GUID", "HTML", "HTTP", "HTTPS",
+	"ID", "IP", "JSON", "LHS", "QPS", "RAM", "RHS", "RPC", "SLA", "SMTP", "SQL", "SSH",
+	"TCP", "TLS", "TTL", "UDP", "UI", "UID", "UUID", "URI", "URL", "UTF8", "VM", "XML",
+	"XMPP", "XSRF", "XSS",
+}
+
+// ReservedEntityNames are package level identifiers generated next to entities
+var ReservedEntityNames = []string{
+	"Columns", "ColumnsSt", "Tables", "TablesSt", "TableInfo", "T", "Column",
+	"DBWrap", "NewDBWrap", "Searcher", "Inet", "Cidr", "MacAddr",
+	"Pager", "NewPager", "Cursor", "ErrInvalidCursor", "PageDirection", "PageNext", "PagePrev",
+	"NullsOrder", "NullsDefault", "NullsFirst", "NullsLast",
+	"SearchError", "SearchErrors", "ErrInvalidValue", "ErrUnknownParam", "ErrUnknownColumn",
+	"FieldError", "ValidationErrors", "ValidationRequired", "ValidationMaxLength", "ValidationEnum",
+	"ValidationPrecision", "ValidationCheck", "ValidationFormat",
+	"Constraint", "ConstraintKind", "ConstraintError", "ConstraintPK", "ConstraintUnique",
+	"ConstraintFK", "ConstraintCheck", "ConstraintExclusion", "TranslateError",
+	"ErrStaleObject", "Optional", "NewOptional", "AuditKey", "WithAuditUser", "CopyBatchSize",
+}
+
+// entity suffixes and prefixes of package level identifiers generated for every entity, e.g. UserRepo, ParseUserSearch
+var (
+	entitySuffixes = []string{"Search", "Repo", "Patch", "Page", "PK", "Columns", "ColumnSet", "Table", "T", "Sort", "SortField"}
+	entityPrefixes = []string{"Columns"}
+	// parsers of search structs, e.g. ParseUserSort
+	entityParsers = []string{"Search", "Sort", "Fields"}
+	// COPY functions are named by plural name
+	pluralPrefixes = []string{"Copy", "CopyOut"}
+)
+
+// EntityIdentifiers gets package level identifiers generated for entity, except entity name itself
+func EntityIdentifiers(goName, goNamePlural string) []string {
+	var result []string
+	for _, suffix := range entitySuffixes {
+		result = append(result, goName+suffix)
+	}
+	for _, prefix := range entityPrefixes {
+		result = append(result, prefix+goName)
+	}
+	for _, parser := range entityParsers {
+		result = append(result, "Parse"+goName+parser)
+	}
+	for _, prefix := range pluralPrefixes {
+		result = append(result, prefix+goNamePlural)
+	}
+
+	return result
+}
+
+// ReservedFieldNames are identifiers generated inside entity structs
+var ReservedFieldNames = []string{
+	"BaseModel", "Apply", "Q", "PK", "Validate",
+}
+
+// Naming is a configurable NamingStrategy
+type Naming struct {
+	initialisms map[string]struct{}
+
+	// singular -> plural and plural -> singular, lower cased
+	plurals   map[string]string
+	singulars map[string]string
+
+	tables  map[string]string
+	columns map[string]string
+}
+
+// NewNaming creates naming strategy
+// initialisms are added to DefaultInitialisms,
+// inflections are singular=plural word pairs (same word for uncountable),
+// tables are renames by schema.table or table for public schema,
+// columns are renames by column name
+func NewNaming(initialisms []string, inflections, tables, columns map[string]string) *Naming {
+	n := &Naming{
+		initialisms: map[string]struct{}{},
+		plurals:     map[string]string{},
+		singulars:   map[string]string{},
+		tables:      map[string]string{},
+		columns:     map[string]string{},
+	}
+
+	for _, list := range [][]string{DefaultInitialisms, initialisms} {
+		for _, i := range list {
+			n.initialisms[strings.ToUpper(i)] = struct{}{}
+		}
+	}
+
+	for singular, plural := range inflections {
+		singular, plural = strings.ToLower(singular), strings.ToLower(plural)
+		n.plurals[singular] = plural
+		n.singulars[plural] = singular
+	}
+
+	for table, name := range tables {
+		schema, table := util.Split(table)
+		n.tables[util.Join(schema, table)] = name
+	}
+
+	for column, name := range columns {
+		n.columns[column] = name
+	}
+
+	return n
+}
+
+var naming NamingStrategy = NewNaming(nil, nil, nil, nil)
+
+// SetNamingStrategy sets strategy used by NewEntity, NewColumn, NewRelation and NewComposite
+func SetNamingStrategy(strategy NamingStrategy) {
+	if strategy == nil {
+		strategy = NewNaming(nil, nil, nil, nil)
+	}
+	naming = strategy
+}
+
+// EntityName gets struct name for table
+func (n *Naming) EntityName(schema, table string) string {
+	if name, ok := n.tables[util.Join(schema, table)]; ok {
+		return Safe(name, ReservedEntityNames)
+	}
+
+	words := n.words(table)
+	for i := len(words) - 1; i >= 0; i-- {
+		if singular, ok := n.singular(words[i]); ok {
+			words[i] = singular
+			break
+		}
+	}
+
+	return Safe(n.schemaPrefix(schema)+n.join(words), ReservedEntityNames)
+}
+
+// EntityNamePlural gets plural name for table
+func (n *Naming) EntityNamePlural(schema, table string) string {
+	words := n.words(table)
+	if len(words) > 0 {
+		// table name is singular already
+		last := len(words) - 1
+		if _, ok := n.singular(words[last]); !ok {
+			words[last] = n.plural(words[last])
+		}
+	}
+
+	return n.schemaPrefix(schema) + n.join(words)
+}
+
+// ColumnName gets struct field name for column
+func (n *Naming) ColumnName(column string) string {
+	if name, ok := n.columns[column]; ok {
+		return Safe(name, nil)
+	}
+
+	return n.join(n.words(column))
+}
+
+// identifier gets go identifier for names which are not columns, e.g. json keys
+func identifier(s string) string {
+	if n, ok := naming.(*Naming); ok {
+		return n.join(n.words(s))
+	}
+	return util.ColumnName(s)
+}
+
+// Initialism makes initialism of word if it is known, e.g. Http -> HTTP, Ids -> IDs
+func (n *Naming) Initialism(word string) string {
+	upper := strings.ToUpper(word)
+	if _, ok := n.initialisms[upper]; ok {
+		return upper
+	}
+
+	if len(word) > 2 && strings.HasSuffix(word, "s") {
+		if _, ok := n.initialisms[upper[:len(upper)-1]]; ok {
+			return upper[:len(upper)-1] + "s"
+		}
+	}
+
+	return word
+}
+
+func (n *Naming) schemaPrefix(schema string) string {
+	if schema == util.PublicSchema {
+		return ""
+	}
+	return n.join(n.words(schema))
+}
+
+func (n *Naming) words(s string) []string {
+	return camelcase.Split(util.CamelCased(util.Sanitize(s)))
+}
+
+func (n *Naming) join(words []string) string {
+	for i, word := range words {
+		words[i] = n.Initialism(word)
+	}
+	return strings.Title(strings.Join(words, ""))
+}
+
+// singular returns singular form if word is plural
+func (n *Naming) singular(word string) (string, bool) {
+	lower := strings.ToLower(word)
+	if singular, ok := n.singulars[lower]; ok {
+		return strings.Title(singular), true
+	}
+	if _, ok := n.plurals[lower]; ok {
+		return word, false
+	}
+
+	singular := inflection.Singular(word)
+	if strings.ToLower(singular) != lower {
+		return strings.Title(singular), true
+	}
+	return word, false
+}
+
+func (n *Naming) plural(word string) string {
+	if plural, ok := n.plurals[strings.ToLower(word)]; ok {
+		return strings.Title(plural)
+	}
+	return inflection.Plural(word)
+}
+
+// SafeEntityNames renames entities which names or identifiers generated for them collide
+// with reserved names, names of other entities or their generated identifiers, e.g. UserSearch of users and user_searches tables
+// plural name gets the same numeric suffix
+func SafeEntityNames(entities []Entity) {
+	// identifiers generated for entities, names of entities are not renamed in favor of them
+	derived := map[string]int{}
+	for i, entity := range entities {
+		for _, identifier := range EntityIdentifiers(entity.GoName, entity.GoNamePlural) {
+			if _, ok := derived[identifier]; !ok {
+				derived[identifier] = i
+			}
+		}
+	}
+
+	taken := util.NewIndex()
+	for _, name := range ReservedEntityNames {
+		taken.Add(name)
+	}
+
+	for i := range entities {
+		name, plural := entities[i].GoName, entities[i].GoNamePlural
+		for suffix := 1; ; suffix++ {
+			if entityNameAvailable(taken, derived, i, name, plural) {
+				break
+			}
+			name = fmt.Sprintf("%s%d", entities[i].GoName, suffix)
+			plural = fmt.Sprintf("%s%d", entities[i].GoNamePlural, suffix)
+		}
+
+		entities[i].GoName, entities[i].GoNamePlural = name, plural
+		taken.Add(name)
+		for _, identifier := range EntityIdentifiers(name, plural) {
+			taken.Add(identifier)
+		}
+	}
+}
+
+// entityNameAvailable checks if name and identifiers generated for i-th entity are not taken
+func entityNameAvailable(taken util.Index, derived map[string]int, i int, name, plural string) bool {
+	if !taken.Available(name) {
+		return false
+	}
+	if j, ok := derived[name]; ok && j != i {
+		return false
+	}
+	for _, identifier := range EntityIdentifiers(name, plural) {
+		if !taken.Available(identifier) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Safe renames identifier if it is a go keyword or one of reserved names
+func Safe(name string, reserved []string) string {
+	if token.Lookup(name).IsKeyword() {
+		return name + "_"
+	}
+
+	index := util.NewIndex()
+	for _, r := range reserved {
+		index.Add(r)
+	}
+
+	return index.GetNext(name)
+}
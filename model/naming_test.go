@@ -0,0 +1,221 @@
+package model
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestNaming_EntityName(t *testing.T) {
+	naming := NewNaming(
+		[]string{"sku"},
+		map[string]string{"metadata": "metadata", "status": "statuses"},
+		map[string]string{"legacy_usr": "User", "geo.places": "Place", "config": "type"},
+		nil,
+	)
+
+	tests := []struct {
+		name       string
+		schema     string
+		table      string
+		want       string
+		wantPlural string
+	}{
+		{
+			name:       "Should singularize last plural word",
+			schema:     "public",
+			table:      "user_orders",
+			want:       "UserOrder",
+			wantPlural: "UserOrders",
+		},
+		{
+			name:       "Should apply initialisms anywhere",
+			schema:     "public",
+			table:      "api_keys",
+			want:       "APIKey",
+			wantPlural: "APIKeys",
+		},
+		{
+			name:       "Should apply custom initialisms",
+			schema:     "public",
+			table:      "sku_prices",
+			want:       "SKUPrice",
+			wantPlural: "SKUPrices",
+		},
+		{
+			name:       "Should use uncountable rule",
+			schema:     "public",
+			table:      "metadata",
+			want:       "Metadata",
+			wantPlural: "Metadata",
+		},
+		{
+			name:       "Should use custom singular rule",
+			schema:     "public",
+			table:      "order_statuses",
+			want:       "OrderStatus",
+			wantPlural: "OrderStatuses",
+		},
+		{
+			name:       "Should pluralize singular table",
+			schema:     "public",
+			table:      "person",
+			want:       "Person",
+			wantPlural: "People",
+		},
+		{
+			name:       "Should rename public table",
+			schema:     "public",
+			table:      "legacy_usr",
+			want:       "User",
+			wantPlural: "LegacyUsrs",
+		},
+		{
+			name:       "Should rename table in schema",
+			schema:     "geo",
+			table:      "places",
+			want:       "Place",
+			wantPlural: "GeoPlaces",
+		},
+		{
+			name:       "Should add schema prefix with initialisms",
+			schema:     "api",
+			table:      "http_logs",
+			want:       "APIHTTPLog",
+			wantPlural: "APIHTTPLogs",
+		},
+		{
+			name:       "Should avoid go keywords",
+			schema:     "public",
+			table:      "config",
+			want:       "type_",
+			wantPlural: "Configs",
+		},
+		{
+			name:       "Should avoid generated identifiers",
+			schema:     "public",
+			table:      "columns",
+			want:       "Column1",
+			wantPlural: "Columns",
+		},
+		{
+			name:       "Should avoid generated identifiers for singular table",
+			schema:     "public",
+			table:      "table_info",
+			want:       "TableInfo1",
+			wantPlural: "TableInfos",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := naming.EntityName(tt.schema, tt.table); got != tt.want {
+				t.Errorf("Naming.EntityName() = %v, want %v", got, tt.want)
+			}
+			if got := naming.EntityNamePlural(tt.schema, tt.table); got != tt.wantPlural {
+				t.Errorf("Naming.EntityNamePlural() = %v, want %v", got, tt.wantPlural)
+			}
+		})
+	}
+}
+
+func TestNaming_ColumnName(t *testing.T) {
+	naming := NewNaming(nil, nil, nil, map[string]string{"usr_id": "UserID", "kind": "type"})
+
+	tests := []struct {
+		name   string
+		column string
+		want   string
+	}{
+		{
+			name:   "Should keep ID suffix",
+			column: "location_id",
+			want:   "LocationID",
+		},
+		{
+			name:   "Should make plural initialism",
+			column: "userIds",
+			want:   "UserIDs",
+		},
+		{
+			name:   "Should apply initialism in the beginning",
+			column: "http_status",
+			want:   "HTTPStatus",
+		},
+		{
+			name:   "Should apply initialism in the middle",
+			column: "last_ip_address",
+			want:   "LastIPAddress",
+		},
+		{
+			name:   "Should not break words",
+			column: "identity",
+			want:   "Identity",
+		},
+		{
+			name:   "Should keep https as a whole",
+			column: "https_only",
+			want:   "HTTPSOnly",
+		},
+		{
+			name:   "Should rename column",
+			column: "usr_id",
+			want:   "UserID",
+		},
+		{
+			name:   "Should avoid go keywords in renames",
+			column: "kind",
+			want:   "type_",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := naming.ColumnName(tt.column); got != tt.want {
+				t.Errorf("Naming.ColumnName() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSetNamingStrategy(t *testing.T) {
+	SetNamingStrategy(NewNaming(nil, nil, nil, map[string]string{"usr_id": "UserID"}))
+	defer SetNamingStrategy(nil)
+
+	column := NewColumn("usr_id", TypePGInt8, false, false, false, 0, false, true, 0, nil, nil)
+	if column.GoName != "UserID" {
+		t.Errorf("NewColumn().GoName = %v, want %v", column.GoName, "UserID")
+	}
+
+	relation := NewRelation([]string{"usr_id"}, "public", "legacy_users", []string{"id"})
+	if relation.GoName != "User" {
+		t.Errorf("NewRelation().GoName = %v, want %v", relation.GoName, "User")
+	}
+	if relation.GoType != "LegacyUser" {
+		t.Errorf("NewRelation().GoType = %v, want %v", relation.GoType, "LegacyUser")
+	}
+
+	entity := NewEntity("public", "legacy_users", []Column{
+		NewColumn("base_model", TypePGText, false, false, false, 0, false, false, 0, nil, nil),
+	}, nil)
+	if got := entity.Columns[0].GoName; got != "BaseModel1" {
+		t.Errorf("NewEntity().Columns[0].GoName = %v, want %v", got, "BaseModel1")
+	}
+}
+
+func TestSafeEntityNames(t *testing.T) {
+	entities := []Entity{
+		NewEntity("public", "optionals", nil, nil),
+		NewEntity("public", "user_searches", nil, nil),
+		NewEntity("public", "users", nil, nil),
+		NewEntity("public", "audit_keys", nil, nil),
+		NewEntity("public", "user", nil, nil),
+	}
+	SafeEntityNames(entities)
+
+	got := make([]string, len(entities))
+	for i, entity := range entities {
+		got[i] = entity.GoName + " " + entity.GoNamePlural
+	}
+	want := []string{"Optional1 Optionals", "UserSearch1 UserSearches1", "User Users", "AuditKey1 AuditKeys", "User1 Users1"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("SafeEntityNames() = %v, want %v", got, want)
+	}
+}
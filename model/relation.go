@@ -25,12 +25,7 @@ type Relation struct {
 func NewRelation(sourceColumns []string, targetSchema, targetTable string, targetColumns []string) Relation {
 	names := make([]string, len(sourceColumns))
 	for i, name := range sourceColumns {
-		names[i] = util.ReplaceSuffix(util.ColumnName(name), util.ID, "")
-	}
-
-	typ := util.EntityName(targetTable)
-	if targetSchema != util.PublicSchema {
-		typ = util.CamelCased(targetSchema) + typ
+		names[i] = util.ReplaceSuffix(naming.ColumnName(name), util.ID, "")
 	}
 
 	return Relation{
@@ -42,7 +37,7 @@ func NewRelation(sourceColumns []string, targetSchema, targetTable string, targe
 		TargetPGSchema:   targetSchema,
 		TargetPGFullName: util.JoinF(targetSchema, targetTable),
 
-		GoType: typ,
+		GoType: naming.EntityName(targetSchema, targetTable),
 	}
 }
 
@@ -2,6 +2,7 @@ package model
 
 import (
 	"fmt"
+	"strings"
 )
 
 const (
@@ -51,6 +52,10 @@ const (
 	TypePGInet = "inet"
 	// TypePGCidr is a postgres type
 	TypePGCidr = "cidr"
+	// TypePGMacaddr is a postgres type
+	TypePGMacaddr = "macaddr"
+	// TypePGMacaddr8 is a postgres type
+	TypePGMacaddr8 = "macaddr8"
 	// TypePGPoint is a postgres type
 	TypePGPoint = "point"
 
@@ -98,7 +103,7 @@ func GoType(pgType string) (string, error) {
 		return TypeFloat32, nil
 	case TypePGNumeric, TypePGFloat8:
 		return TypeFloat64, nil
-	case TypePGText, TypePGVarchar, TypePGUuid, TypePGBpchar, TypePGPoint:
+	case TypePGText, TypePGVarchar, TypePGUuid, TypePGBpchar, TypePGPoint, TypePGMacaddr, TypePGMacaddr8:
 		return TypeString, nil
 	case TypePGBytea:
 		return TypeByteSlice, nil
@@ -134,16 +139,17 @@ func GoSlice(pgType string, dimensions int) (string, error) {
 		return "", err
 	}
 
+	return goSliceOf(typ, dimensions), nil
+}
+
+// goSliceOf generates go slice type of given go type
+func goSliceOf(typ string, dimensions int) string {
 	// slice can not have 0 dimensions
 	if dimensions == 0 {
 		dimensions = 1
 	}
 
-	for i := 0; i < dimensions; i++ {
-		typ = fmt.Sprintf("[]%s", typ)
-	}
-
-	return typ, nil
+	return strings.Repeat("[]", dimensions) + typ
 }
 
 // GoNullable generates all go types from Postgres type with pointer
@@ -157,7 +163,7 @@ func GoNullable(pgType string, useSQLNull bool, customTypes CustomTypeMapping) (
 			return "sql.NullFloat64", nil
 		case TypePGBool:
 			return "sql.NullBool", nil
-		case TypePGText, TypePGVarchar, TypePGUuid, TypePGBpchar, TypePGPoint:
+		case TypePGText, TypePGVarchar, TypePGUuid, TypePGBpchar, TypePGPoint, TypePGMacaddr, TypePGMacaddr8:
 			return "sql.NullString", nil
 		case TypePGTimestamp, TypePGTimestamptz, TypePGDate, TypePGTime, TypePGTimetz:
 			return "bun.NullTime", nil
@@ -189,7 +195,7 @@ func GoImport(pgType string, nullable, useSQLNull bool) string {
 		case TypePGInt2, TypePGInt4, TypePGInt8,
 			TypePGNumeric, TypePGFloat4, TypePGFloat8,
 			TypePGBool,
-			TypePGText, TypePGVarchar, TypePGUuid, TypePGBpchar, TypePGPoint:
+			TypePGText, TypePGVarchar, TypePGUuid, TypePGBpchar, TypePGPoint, TypePGMacaddr, TypePGMacaddr8:
 			return "database/sql"
 		case TypePGTimestamp, TypePGTimestamptz, TypePGDate, TypePGTime, TypePGTimetz:
 			return "github.com/uptrace/bun"
@@ -82,6 +82,11 @@ func Test_goType(t *testing.T) {
 			pgTypes: []string{TypePGCidr},
 			want:    TypeIPNet,
 		},
+		{
+			name:    "Should get string for mac address",
+			pgTypes: []string{TypePGMacaddr, TypePGMacaddr8},
+			want:    TypeString,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
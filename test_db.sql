@@ -26,16 +26,31 @@ create table "users"
     "apiKeys"   bytea[],
     "loggedAt"  timestamp,
 
-    primary key ("userId")
+    primary key ("userId"),
+    constraint "users_email_key" unique ("email"),
+    constraint "users_email_check" check ("email" ~ '@')
 );
 
+create index "users_countryId_name_idx" on "users" ("countryId", "name");
+
 create schema "geo";
 
+create type geo."location" as
+(
+    "lat"   float8,
+    "lng"   float8,
+    "label" text
+);
+
+create domain geo."countryCode" as varchar(3) not null
+    check (value ~ '^[A-Z]{3}$');
+
 create table geo."countries"
 (
-    "countryId" serial     not null,
-    "code"      varchar(3) not null,
+    "countryId" serial            not null,
+    "code"      geo."countryCode" not null,
     "coords"    integer[],
+    "capital"   geo."location",
 
     primary key ("countryId")
 );
@@ -45,3 +45,31 @@ func (a *Annotation) String() string {
 
 	return strings.Join(result, " ")
 }
+
+// ParseAnnotation parses tags in format "name:value name2:value2"
+func ParseAnnotation(raw string) (*Annotation, error) {
+	a := NewAnnotation()
+	for _, field := range strings.Fields(raw) {
+		split := strings.SplitN(field, ":", 2)
+		if len(split) < 2 || split[0] == "" || split[1] == "" {
+			return nil, fmt.Errorf("tag %q has invalid format (expected name:value)", field)
+		}
+		a.AddTag(split[0], strings.Trim(split[1], `"`))
+	}
+
+	return a, nil
+}
+
+// Merge appends all tags from other annotation
+func (a *Annotation) Merge(other *Annotation) *Annotation {
+	if other == nil {
+		return a
+	}
+	for _, tag := range other.tags {
+		for _, value := range tag.values {
+			a.AddTag(tag.name, value)
+		}
+	}
+
+	return a
+}
@@ -87,3 +87,50 @@ func TestAnnotation_String(t *testing.T) {
 		})
 	}
 }
+
+func TestParseAnnotation(t *testing.T) {
+	tests := []struct {
+		name    string
+		raw     string
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "Should parse one tag",
+			raw:  "validate:required",
+			want: `validate:"required"`,
+		},
+		{
+			name: "Should parse several tags",
+			raw:  `validate:"email" json:mail`,
+			want: `validate:"email" json:"mail"`,
+		},
+		{
+			name:    "Should error on missing value",
+			raw:     "validate",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseAnnotation(tt.raw)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ParseAnnotation() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if err == nil && got.String() != tt.want {
+				t.Errorf("ParseAnnotation() = %v, want %v", got.String(), tt.want)
+			}
+		})
+	}
+}
+
+func TestAnnotation_Merge(t *testing.T) {
+	a := NewAnnotation().AddTag("bun", "email").AddTag("json", "email")
+	b := NewAnnotation().AddTag("bun", "unique").AddTag("validate", "email")
+
+	want := `bun:"email,unique" json:"email" validate:"email"`
+	if got := a.Merge(b).String(); got != want {
+		t.Errorf("Annotation.Merge() = %v, want %v", got, want)
+	}
+}
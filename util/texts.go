@@ -163,3 +163,23 @@ func LowerFirst(s string) string {
 	r, n := utf8.DecodeRuneInString(s)
 	return string(unicode.ToLower(r)) + s[n:]
 }
+
+// LowerCamel makes lowerCamelCase from CamelCase keeping initialisms together, e.g. APIKey -> apiKey, IDs -> ids
+func LowerCamel(s string) string {
+	upper := 0
+	for upper < len(s) && IsUpper(s[upper]) {
+		upper++
+	}
+
+	switch {
+	case upper == len(s):
+		return strings.ToLower(s)
+	case upper <= 1:
+		return LowerFirst(s)
+	case s[upper:] == "s" || (s[upper] == 's' && upper+1 < len(s) && IsUpper(s[upper+1])):
+		// plural initialism, e.g. IDs, URLsList
+		return strings.ToLower(s[:upper]) + s[upper:]
+	default:
+		return strings.ToLower(s[:upper-1]) + s[upper-1:]
+	}
+}
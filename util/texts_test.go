@@ -492,3 +492,49 @@ func TestLowerFirst(t *testing.T) {
 		})
 	}
 }
+
+func TestLowerCamel(t *testing.T) {
+	tests := []struct {
+		name string
+		s    string
+		want string
+	}{
+		{
+			name: "Should convert Word to word",
+			s:    "Word",
+			want: "word",
+		},
+		{
+			name: "Should convert ID to id",
+			s:    "ID",
+			want: "id",
+		},
+		{
+			name: "Should convert APIKey to apiKey",
+			s:    "APIKey",
+			want: "apiKey",
+		},
+		{
+			name: "Should convert IDs to ids",
+			s:    "IDs",
+			want: "ids",
+		},
+		{
+			name: "Should convert URLsList to urlsList",
+			s:    "URLsList",
+			want: "urlsList",
+		},
+		{
+			name: "Should convert CountryID to countryID",
+			s:    "CountryID",
+			want: "countryID",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := LowerCamel(tt.s); got != tt.want {
+				t.Errorf("LowerCamel() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}